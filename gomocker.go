@@ -1,14 +1,29 @@
 package gomocker
 
+//go:generate go run ./internal/typedgen
+
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"go/token"
+	"math"
+	"path/filepath"
 	"reflect"
 	"runtime"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+	"unicode/utf8"
 	"unsafe"
 
 	"github.com/agiledragon/gomonkey/v2"
+	"github.com/agiledragon/gomonkey/v2/creflect"
 )
 
 // Mocker is the major interface for mocker library
@@ -27,6 +42,148 @@ type Mocker interface {
 	//   expectFunc pass in the pointer to the function to be mocked
 	//   returns a Returner instance to allow setting up return expectations
 	Stub(expectFunc interface{}) Returner
+	// MockFuncVar allows one to mock a function variable, e.g. `var now = time.Now`, visible to the current package
+	//   only the calls through the variable are affected, and the variable is restored at the end of test
+	//
+	//   target pass in the pointer to the function variable to be mocked
+	//   returns an Expecter instance to allow setting up parameter expectations
+	MockFuncVar(target interface{}) Expecter
+	// MockVar allows one to patch a package-level variable, e.g. `var maxRetries = 3`, with the given value during the test
+	//   the variable is restored to its original value at the end of test, while patching it again simply overrides the value,
+	//   and without a value the function variable is mocked instead, exactly as MockFuncVar does
+	//
+	//   target pass in the pointer to the variable to be patched
+	//   value pass in the value assignable to the variable for the rest of the test, or nothing to mock a function variable
+	//   returns an Expecter instance to allow setting up parameter expectations when mocking a function variable
+	MockVar(target interface{}, value ...interface{}) Expecter
+	// MockMethod allows one to mock a struct method by its name, e.g. an unexported method of a type from another package
+	//   the receiver is passed in as the first parameter of the calls, as if mocking the method expression,
+	//   while the signature of an unexported method must be given, as it is not known to reflect
+	//
+	//   target pass in a value of the receiver type, e.g. (*foo)(nil) for the methods with pointer receiver
+	//   methodName pass in the name of the struct method to be mocked
+	//   signature pass in a function value of the method expression's type for an unexported method, e.g. (func(*foo, string) error)(nil)
+	//   returns an Expecter instance to allow setting up parameter expectations
+	MockMethod(target interface{}, methodName string, signature ...interface{}) Expecter
+	// StubMethod allows one to stub a struct method by its name, e.g. an unexported method of a type from another package
+	//
+	//   target pass in a value of the receiver type, e.g. (*foo)(nil) for the methods with pointer receiver
+	//   methodName pass in the name of the struct method to be stubbed
+	//   signature pass in a function value of the method expression's type for an unexported method, e.g. (func(*foo, string) error)(nil)
+	//   returns a Returner instance to allow setting up return expectations
+	StubMethod(target interface{}, methodName string, signature ...interface{}) Returner
+	// MockOn allows one to mock a struct method only for the calls on the given instance
+	//   the calls on other instances are served by the setups from Mock or Stub method as usual
+	//
+	//   instance pass in the pointer to the struct instance, i.e. the receiver of the calls to be mocked
+	//   expectFunc pass in the struct method to be mocked, e.g. (*foo).bar
+	//   returns an Expecter instance to allow setting up parameter expectations
+	MockOn(instance interface{}, expectFunc interface{}) Expecter
+	// InOrder allows one to verify that the given setups are called in the exact order as they are passed in
+	//   a setup must be fully called for its expected number of times before its successor is called,
+	//   while other setups are not affected
+	//
+	//   setups pass in the results of Once/Twice/Times/AtLeast/AnyTimes methods from the setups to be ordered
+	InOrder(setups ...Mocker)
+	// Sequence creates a named sequence to verify that the setups attached to it are called in their attachment order
+	//   setups in different sequences are not ordered relative to each other
+	//
+	//   name pass in the name of the sequence shown in failure messages, e.g. "read pipeline"
+	//   returns a Sequence instance to be attached to setups via In method
+	Sequence(name string) *Sequence
+	// AssertCalledWith verifies that at least one call to the given function or struct method so far matches the parameters
+	//   this is useful when the expected parameters are only known after executing the SUT
+	//
+	//   expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+	//   parameters pass in the list of parameters to be verified, just like how they are passed into Expects method
+	AssertCalledWith(expectFunc interface{}, parameters ...any)
+	// AssertNotCalledWith verifies that no call to the given function or struct method so far matches the parameters
+	//
+	//   expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+	//   parameters pass in the list of parameters to be verified, just like how they are passed into Expects method
+	AssertNotCalledWith(expectFunc interface{}, parameters ...any)
+	// AssertNothingCalled verifies that no function or struct method setup so far has been called, for both mocks and stubs
+	//   this is useful when the SUT is expected to short-circuit before touching any of its dependencies
+	AssertNothingCalled()
+	// CallsOf returns the calls to the given function or struct method so far, for both mocks and stubs
+	//   this is useful to verify the relationships between calls after executing the SUT
+	//
+	//   expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+	//   returns the list of calls in the order they are made
+	CallsOf(expectFunc interface{}) []Call
+	// ReturnedValues returns the values returned to the callers of the given function or struct method so far
+	//   this is useful to verify the dynamic returns, e.g. from ConditionalReturn, produced the expected values
+	//
+	//   expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+	//   returns the list of returned values for each call in the order they are made
+	ReturnedValues(expectFunc interface{}) [][]any
+	// CallCount returns the number of calls to the given function or struct method so far, for both mocks and stubs
+	//
+	//   expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+	//   returns the number of calls, or 0 if the function or struct method was never setup
+	CallCount(expectFunc interface{}) int
+	// TotalCalls returns the number of calls to all functions or struct methods setup so far, for both mocks and stubs
+	//
+	//   returns the total number of calls made to this mocker
+	TotalCalls() int
+	// Satisfied returns whether the calls to the given function or struct method so far meet the expected number of calls
+	//   this is useful to poll for the calls made asynchronously by the SUT before proceeding with the test
+	//
+	//   expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+	//   returns true if the expected number of calls is met, or false if not or the function or struct method was never setup
+	Satisfied(expectFunc interface{}) bool
+	// CleanupCalled returns whether all the func-typed returns tracked by TrackCleanup have been invoked so far
+	//   this is useful to verify that the SUT releases the resources acquired from the given function or struct method
+	//
+	//   expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+	//   returns true if every tracked non-nil func returned so far has been invoked at least once, or false otherwise,
+	//     including when nothing has been tracked or the function or struct method was never setup
+	CleanupCalled(expectFunc interface{}) bool
+	// WaitForCall blocks until the number of calls to the given function or struct method reaches the count
+	//   the test fails with the current number of calls if the count is not reached before the timeout elapses
+	//   this is useful to wait for the calls made asynchronously by the SUT, and can be used repeatedly for each phase
+	//
+	//   expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+	//   count pass in the total number of calls to wait for, for both mocks and stubs
+	//   timeout pass in the maximum duration to wait
+	//   returns true if the count is reached, or false otherwise
+	WaitForCall(expectFunc interface{}, count int, timeout time.Duration) bool
+	// Verify verifies all mocks setup so far immediately, instead of waiting for the end of test
+	//   the verified mocks are not verified again at the end of test, while the patches are kept in place,
+	//   so that new mocks can be setup for the same functions or struct methods in the next phase of test
+	Verify()
+	// ExpectationsWereMet verifies all mocks setup so far the same way as at the end of test, without reporting to the tester
+	//   typically used together with WithoutCleanupVerification option to report failures in a custom way
+	//
+	//   returns an error joining the descriptions of all failures in the order of setups followed by the calls out of order, or nil if all are met
+	ExpectationsWereMet() error
+	// UnmetExpectations returns the names of all mocks setup so far whose number of calls is not as expected
+	//
+	//   returns the list of names in the order of setups, or an empty list if all are met
+	UnmetExpectations() []string
+	// Close verifies all mocks and resets all patches immediately, instead of waiting for the end of test
+	//   this is safe to be deferred or called multiple times, as only the first call takes effect,
+	//   which is useful for a mocker whose tester does not run the cleanup functions
+	Close()
+	// Scope creates a child mocker for a subtest, whose mocks are verified and patches are reset at the end of the subtest
+	//   the child mocker shares no setups or patches with the current one, but is created with the same options
+	//
+	//   tester simply pass in the Golang testing struct from the subtest method
+	//   returns the child mocker to setup the mocks or stubs only effective during the subtest
+	Scope(tester testing.TB) Mocker
+	// Derive creates a child mocker falling back to the setups of the current one, whose own setups are verified independently
+	//   the calls to the functions or struct methods not setup by the child are served and recorded by the current mocker,
+	//   while a setup in the child takes over the calls to its function or struct method until the child is verified
+	//
+	//   returns the child mocker to setup further mocks or stubs
+	Derive() Mocker
+}
+
+// Sequence is a named group of setups to be called in their attachment order
+//
+//	refer to README.md for more details and examples
+type Sequence struct {
+	order *ordering
 }
 
 // Expecter is the interface for setting up parameter expectations
@@ -39,10 +196,58 @@ type Expecter interface {
 	//     just like how they are normally passed into the original function or struct method
 	//   returns a Returner instance to allow setting up return expectations
 	Expects(parameters ...any) Returner
+	// Named allows one to override the name of the underlying function or struct method shown in failure messages
+	//
+	//   name pass in the customized display name, e.g. "Foo"
+	//   returns the same Expecter instance to allow setting up parameter expectations
+	Named(name string) Expecter
+	// Labeled allows one to attach a label to the current setup shown in its failure messages along with the name
+	//
+	//   label pass in the human-readable label of the setup, e.g. "cache miss case"
+	//   returns the same Expecter instance to allow setting up parameter expectations
+	Labeled(label string) Expecter
+	// ExpectsAnyOrder allows one to setup lists of parameters to be verified in any order across calls
+	//   the calls are collected and verified as an unordered multiset against the lists at the end of test
+	//   typically one would complete the setup with Times(len(valueSets)) to expect one call per list
+	//
+	//   valueSets pass in the lists of parameters to be verified, each list for one call,
+	//     just like how they are normally passed into the original function or struct method
+	//   returns a Returner instance to allow setting up return expectations
+	ExpectsAnyOrder(valueSets ...[]any) Returner
+	// ExpectsDistinct allows one to verify that no two calls to the underlying function or struct method share the same arguments
+	//   the parameters of each call are not verified otherwise, while duplicates are reported at the end of test
+	//   this applies to all calls of the underlying function or struct method, e.g. for idempotency tests
+	//
+	//   returns a Returner instance to allow setting up return expectations
+	ExpectsDistinct() Returner
+	// VariadicAsSlice allows one to verify the variadic parameters of the underlying function or struct method
+	//   as a single slice parameter, instead of expanding them and verifying one by one
+	//   this applies to all setups of the underlying function or struct method
+	//
+	//   returns the same Expecter instance to allow setting up parameter expectations
+	VariadicAsSlice() Expecter
+	// Unordered allows one to dispatch each call to the underlying function or struct method
+	//   to whichever remaining setup matching its parameters, instead of consuming setups in order
+	//   this applies to all setups of the underlying function or struct method
+	//
+	//   returns the same Expecter instance to allow setting up parameter expectations
+	Unordered() Expecter
+	// Formatted allows one to customize how the values are rendered in failure messages
+	//   the formatter is used for the values in parameter mismatches and the arguments of unexpected calls,
+	//   e.g. to redact secrets or truncate big blobs, and this applies to all setups of the underlying function or struct method
+	//
+	//   formatter pass in the function rendering each value into the text shown in failure messages
+	//   returns the same Expecter instance to allow setting up parameter expectations
+	Formatted(formatter func(value any) string) Expecter
 	// NotCalled verifies that no call is expected to the underlying function or struct method
 	//   the underlying function or struct method cannot be mocked or stubbed again in the same test
 	//   this completes the current Mock sequence, as well as overrides any previous mock or stub
 	NotCalled()
+	// Never verifies that no call is expected to the underlying function or struct method, unless setup again later
+	//   unlike NotCalled, the underlying function or struct method can be mocked or stubbed again in the same test,
+	//   in which case the new setup replaces the expectation of no call from then on
+	//   this completes the current Mock sequence, as well as overrides any previous mock or stub
+	Never()
 }
 
 // Returner is the interface for setting up return expectations
@@ -55,6 +260,54 @@ type Returner interface {
 	//     just like how they are normally returned from the original function or struct method
 	//   returns a Counter instance to allow setting up execution expectations
 	Returns(values ...any) Counter
+	// ReturnsSequence allows one to setup groups of values to be returned in order by consecutive calls
+	//   the final group is repeated for all remaining calls, which fits well with AnyTimes or AtLeast
+	//
+	//   groups pass in the groups of values to be returned, each group for one call,
+	//     just like how they are normally returned from the original function or struct method
+	//   returns a Counter instance to allow setting up execution expectations
+	ReturnsSequence(groups ...[]any) Counter
+	// ReturnsCycle allows one to setup groups of values to be returned in a repeating cycle by consecutive calls
+	//   after the final group is returned, the cycle starts over from the first group, which fits well with AnyTimes
+	//
+	//   groups pass in the groups of values to be returned, each group for one call,
+	//     just like how they are normally returned from the original function or struct method
+	//   returns a Counter instance to allow setting up execution expectations
+	ReturnsCycle(groups ...[]any) Counter
+	// Return is an alias of Returns for an easier migration from gomock
+	Return(values ...any) Counter
+	// ReturnsError allows one to setup an error to be returned after a function or a struct method call
+	//   all other returns are setup with zero values, and the last return must be of type error
+	//
+	//   err pass in the error to be returned at the last return position
+	//   returns a Counter instance to allow setting up execution expectations
+	ReturnsError(err error) Counter
+	// ReturnsZero allows one to setup zero values to be returned after a function or a struct method call
+	//   this documents the intent more clearly than enumerating the zero values in Returns
+	//
+	//   returns a Counter instance to allow setting up execution expectations
+	ReturnsZero() Counter
+	// ReturnsFromChannel allows one to feed the values to be returned from a channel during each call
+	//   each call blocks until the list of values to be returned for that call is received from the channel
+	//
+	//   channel pass in the channel to send the list of values to be returned for each call,
+	//     just like how they are normally returned from the original function or struct method
+	//   returns a Counter instance to allow setting up execution expectations
+	ReturnsFromChannel(channel <-chan []any) Counter
+	// ReturnsCopy allows one to setup a list of values to be deep copied and returned after each call
+	//   this prevents the mutations on the returned values by one call from affecting subsequent calls
+	//   values implementing a Clone or Copy method returning their own type are copied by that method,
+	//     otherwise unexported struct fields are copied shallowly as a best effort
+	//
+	//   values pass in the list of values to be deep copied and returned,
+	//     just like how they are normally returned from the original function or struct method
+	//   returns a Counter instance to allow setting up execution expectations
+	ReturnsCopy(values ...any) Counter
+	// Labeled allows one to attach a label to the current setup shown in its failure messages along with the name
+	//
+	//   label pass in the human-readable label of the setup, e.g. "cache miss case"
+	//   returns the same Returner instance to allow setting up return expectations
+	Labeled(label string) Returner
 }
 
 // Returner is the interface for setting up execution expectations
@@ -69,72 +322,446 @@ type Counter interface {
 	//     and `params` are the exact arguments passed into the underlying function or struct method
 	//   returns the same Counter instance to allow setting up further execution expectations
 	SideEffect(callback func(index int, params ...interface{})) Counter
+	// PostSideEffect allows one to setup a callback function that is called after the returns are constructed
+	//   note that there is only one post side effect for each mock or stub, and the newest overrides previous ones
+	//
+	//   callback pass in the customized callback function with an integer parameter `index`
+	//     this parameter indicates the number of executions done so far including the current one
+	//     and `returns` are the exact values returned from the underlying function or struct method
+	//   returns the same Counter instance to allow setting up further execution expectations
+	PostSideEffect(callback func(index int, returns ...interface{})) Counter
+	// ConditionalReturn allows one to setup a callback function that decides the returns of each call at call time
+	//   note that there is only one conditional return for each mock or stub, and the newest overrides previous ones
+	//
+	//   fn pass in the customized callback function with `args` being the exact arguments passed into
+	//     the underlying function or struct method, returning `use` as true to override the static returns
+	//     with the `returns` given, or false to leave the static returns in effect for the call
+	//   returns the same Counter instance to allow setting up further execution expectations
+	ConditionalReturn(fn func(args []any) (returns []any, use bool)) Counter
+	// Notify allows one to setup a channel to be notified each time the current mock or stub is executed
+	//   the notification is sent after the side effects run and the returns are constructed, before returning to the SUT
+	//   note that sending the notification blocks the call until received, unless the channel is buffered
+	//
+	//   ch pass in the channel to be notified, which receives one notification for each execution
+	//   returns the same Counter instance to allow setting up further execution expectations
+	Notify(ch chan<- struct{}) Counter
+	// TrackCleanup allows one to track the invocations of the func-typed returns of the current mock or stub
+	//   e.g. the cleanup closure returned from a function like `func() (resource, func())`
+	//   the returned funcs are wrapped to count their invocations, which are then verified using CleanupCalled method
+	//
+	//   returns the same Counter instance to allow setting up further execution expectations
+	TrackCleanup() Counter
+	// Do is a gomock style alias of SideEffect for an easier migration from gomock
+	//
+	//   action pass in the customized callback function having the same parameters as the underlying
+	//     function or struct method, which is called with the exact arguments passed in
+	//   returns the same Counter instance to allow setting up further execution expectations
+	Do(action any) Counter
 	// Once allows one to quickly setup only once execution for the current mock or stub
 	//   this is equivalent to call Times(1)
-	Once() Mocker
+	Once() Expectation
 	// Once allows one to quickly setup only twice executions for the current mock or stub
 	//   this is equivalent to call Times(2)
-	Twice() Mocker
+	Twice() Expectation
 	// Times allows one to setup the number of executions for the current mock or stub
 	//
 	//   count pass in the number of executions expected, and must be a positive number
-	Times(count int) Mocker
+	Times(count int) Expectation
+	// TimesIf allows one to setup the number of executions for the current mock or stub depending on a condition
+	//   when the condition is false, no execution is expected for the current mock or stub
+	//
+	//   cond pass in the condition whether the executions are expected, e.g. a flag computed at setup
+	//   count pass in the number of executions expected when cond is true, and must be a positive number
+	TimesIf(cond bool, count int) Expectation
+	// AtLeast allows one to setup the minimum number of executions for the current mock or stub
+	//   calls beyond the minimum are served by the current mock or stub, thus it must be the last setup
+	//
+	//   count pass in the minimum number of executions expected, and must not be a negative number
+	AtLeast(count int) Expectation
+	// AnyTimes allows one to setup any number of executions for the current mock or stub
+	//   this is equivalent to call AtLeast(0)
+	AnyTimes() Expectation
+	// Labeled allows one to attach a label to the current setup shown in its failure messages along with the name
+	//
+	//   label pass in the human-readable label of the setup, e.g. "cache miss case"
+	//   returns the same Counter instance to allow setting up further execution expectations
+	Labeled(label string) Counter
+}
+
+// Expectation is a completed setup of a mock or stub, i.e. the result of Once/Twice/Times/AtLeast/AnyTimes methods
+//
+//	besides further setups through the embedded Mocker, it allows one to order or label the completed setup
+type Expectation interface {
+	Mocker
+	// After allows one to verify that the current setup is only called after the given setups are fully called
+	//
+	//   setups pass in the results of Once/Twice/Times/AtLeast/AnyTimes methods from the prerequisite setups
+	//   returns the same Expectation instance to allow further setups
+	After(setups ...Mocker) Expectation
+	// Requires allows one to verify that the current setup is only called after the given setups' functions are called
+	//   unlike After, the prerequisite functions or struct methods only need to be called at least once, not fully
+	//
+	//   setups pass in the results of Once/Twice/Times/AtLeast/AnyTimes methods from the prerequisite setups
+	//   returns the same Expectation instance to allow further setups
+	Requires(setups ...Mocker) Expectation
+	// In attaches the current setup to the given sequence, after all setups previously attached to it
+	//
+	//   sequence pass in the Sequence instance created by Sequence method
+	//   returns the same Expectation instance to allow further setups
+	In(sequence *Sequence) Expectation
+	// Labeled allows one to attach a label to the current setup shown in its failure messages along with the name
+	//
+	//   label pass in the human-readable label of the setup, e.g. "cache miss case"
+	//   returns the same Expectation instance to allow further setups
+	Labeled(label string) Expectation
+	// Within allows one to wait for the expected number of calls to the current setup at verification
+	//   the calls made asynchronously by the SUT after the test body ends are counted, until the timeout elapses,
+	//   and this applies to all setups of the underlying function or struct method
+	//
+	//   timeout pass in the maximum duration to wait at verification for the expected number of calls
+	//   returns the same Expectation instance to allow further setups
+	Within(timeout time.Duration) Expectation
 }
 
 type mockEntry struct {
 	parameters []interface{}
 	returns    []interface{}
 	callback   func(int, ...interface{})
+	postback   func(int, ...interface{})
+	override   func([]any) ([]any, bool)
+	notify     chan<- struct{}
+	tracking   bool
+	distinct   bool
+	anyOrder   bool
+	copying    bool
+	zero       bool
+	sequence   [][]interface{}
+	cyclic     bool
+	channel    <-chan []interface{}
+	position   int
+	times      int
+	used       int
+	orderings  []*ordering
+	afters     []*expectation
+	requires   []*funcEntry
+	declared   string
+	label      string
 }
 
-type funcEntry struct {
+type ordering struct {
 	name     string
-	stub     bool
-	expect   int
-	actual   int
-	nocall   bool
-	verified bool
 	mocks    []*mockEntry
+	names    []string
+	observed []string
+}
+
+type expectation struct {
+	*mocker
+	entry *funcEntry
+	mock  *mockEntry
+}
+
+// returner is the Returner stage of a setup, whose Labeled keeps the chain at the Returner stage
+type returner struct {
+	*mocker
+}
+
+// counter is the Counter stage of a setup, whose Labeled keeps the chain at the Counter stage
+type counter struct {
+	*mocker
+}
+
+type funcEntry struct {
+	name      string
+	funcType  reflect.Type
+	stub      bool
+	expect    int
+	actual    int
+	nocall    bool
+	never     bool
+	distinct  bool
+	auto      bool
+	asSlice   bool
+	unordered bool
+	verified  bool
+	unbounded bool
+	blocked   atomic.Int64
+	mocks     []*mockEntry
+	anyOrders [][]interface{}
+	collected [][]reflect.Value
+	history   []*invocation
+	instances map[uintptr]*funcEntry
+	order     int
+	formatter func(any) string
+	within    time.Duration
+}
+
+type invocation struct {
+	args     []reflect.Value
+	returns  []reflect.Value
+	callers  []string
+	cleanups []*atomic.Int64
+}
+
+// Call is the record of a call to a mocked or stubbed function or struct method
+type Call struct {
+	// Index is the 1-based index of the call among all calls to the same function or struct method
+	Index int
+	// Args is the list of parameters passed into the call, with the variadic parameters as a single slice
+	Args []any
+	// Returns is the list of values returned from the call
+	Returns []any
 }
 
 type mocker struct {
-	tester  testing.TB
-	patches patcher
-	entries map[uintptr]*funcEntry
-	locker  sync.Locker
-	current *funcEntry
-	temp    *mockEntry
+	tester     testing.TB
+	patches    patcher
+	entries    map[uintptr]*funcEntry
+	locker     sync.Locker
+	current    *funcEntry
+	temp       *mockEntry
+	done       chan struct{}
+	copying    bool
+	setups     int
+	funcs      int
+	strict     bool
+	declared   []*expectation
+	next       int
+	failed     atomic.Bool
+	manual     bool
+	closed     bool
+	aborted    atomic.Bool
+	verbose    bool
+	unused     bool
+	debug      bool
+	stack      bool
+	fatal      bool
+	summarized bool
+	callers    int
+	called     chan struct{}
+	claims     []uintptr
+	applied    int
+	options    []Option
+	overPanic  bool
+	auto       bool
+	late       bool
+	calls      int
+	timeline   []*callRecord
+	variables  []*patchedVariable
+	nilEmpty   bool
+	parent     *mocker
+	disorders  []*mismatch
+}
+
+type patchedVariable struct {
+	target   reflect.Value
+	original reflect.Value
+}
+
+type callRecord struct {
+	sequence int
+	name     string
+	calls    int
+	args     string
 }
 
 type patcher interface {
 	ApplyCore(target, double reflect.Value) *gomonkey.Patches
+	ApplyPrivateMethod(target interface{}, methodName string, double interface{}) *gomonkey.Patches
 	Reset()
 }
 
+// Option is the type for customizing the behaviors of a mocker when creating it
+type Option func(m *mocker)
+
+// WithCopiedReturns makes all mocks and stubs deep copy their returns for each call, as if using ReturnsCopy
+func WithCopiedReturns() Option {
+	return func(m *mocker) {
+		m.copying = true
+	}
+}
+
+// WithStrictOrder makes all mocks verified to be called in the exact order as they are setup across functions
+//
+//	the first call out of order fails the test, while stubs are not affected
+func WithStrictOrder() Option {
+	return func(m *mocker) {
+		m.strict = true
+	}
+}
+
+// WithCallerInfo makes the caller of each call to the mocked functions or struct methods recorded
+//
+//	the callers of the calls beyond the expected number of calls are reported along with the failures
+func WithCallerInfo() Option {
+	return func(m *mocker) {
+		m.callers = max(m.callers, 1)
+	}
+}
+
+// WithCallerDepth makes the callers of each call to the mocked functions or struct methods recorded up to the depth
+//
+//	depth pass in the number of frames recorded for each call, starting from the immediate caller
+func WithCallerDepth(depth int) Option {
+	return func(m *mocker) {
+		m.callers = depth
+	}
+}
+
+// WithStrictParams makes parameter mismatches fail the test immediately, instead of continuing the call
+//
+//	this stops the SUT before running on with the returns of a mismatched call, while other failures are not affected
+func WithStrictParams() Option {
+	return func(m *mocker) {
+		m.fatal = true
+	}
+}
+
+// WithoutCleanupVerification skips the verification of mocks at the end of test, while the patches are still reset
+//
+//	unexpected calls beyond the expected number of calls or out of order are not reported to the tester either,
+//	typically used together with ExpectationsWereMet method to report failures in a custom way
+func WithoutCleanupVerification() Option {
+	return func(m *mocker) {
+		m.manual = true
+	}
+}
+
+// WithVerboseValues makes all values rendered in full in parameter mismatch failures
+//
+//	by default a value is cut off at a few hundred characters, and a long byte slice is rendered as a short hexdump
+func WithVerboseValues() Option {
+	return func(m *mocker) {
+		m.verbose = true
+	}
+}
+
+// WithReportUnusedStubs makes all stubs verified to be called at least once at the end of test
+//
+//	this helps to clean up the stubs for functions or struct methods no longer called by the SUT
+func WithReportUnusedStubs() Option {
+	return func(m *mocker) {
+		m.unused = true
+	}
+}
+
+// WithDebugLog makes every call to the mocked functions or struct methods logged to the tester
+//
+//	each log shows the call with its arguments, the setup handling the call and the returns,
+//	  which are only displayed for failing tests unless running tests in verbose mode
+func WithDebugLog() Option {
+	return func(m *mocker) {
+		m.debug = true
+	}
+}
+
+// WithPanicStack makes the stack trace included when reporting a panic recovered from a mocked call
+//
+//	this helps to locate the panic raised from within a side effect, at the cost of a much longer failure message
+func WithPanicStack() Option {
+	return func(m *mocker) {
+		m.stack = true
+	}
+}
+
+// WithPanicOnOverCall makes the calls beyond the expected number of calls panic after the failure is reported
+//
+//	instead of returning zero values to the SUT, which helps to pinpoint runaway call loops by the stack trace
+func WithPanicOnOverCall() Option {
+	return func(m *mocker) {
+		m.overPanic = true
+	}
+}
+
+// WithAutoStub makes the calls reaching the mocker without any setup return zero values instead of failing the test
+//
+//	the first such call of each function or method is logged, while all are recorded for CallsOf, CallCount or the call timeline
+//	note that only the functions or methods patched by the mocker can be reached, i.e. the ones setup at some point
+func WithAutoStub() Option {
+	return func(m *mocker) {
+		m.auto = true
+	}
+}
+
+// WithNilAndEmptyEqual makes nil and empty slices or maps of the same type equal when comparing parameters
+//
+//	this avoids mismatches only caused by the SUT constructing an empty slice or map instead of passing nil, or vice versa,
+//	while the nested slices or maps, e.g. the fields of a struct, are still compared with reflect.DeepEqual
+func WithNilAndEmptyEqual() Option {
+	return func(m *mocker) {
+		m.nilEmpty = true
+	}
+}
+
+// overCall is the panic value for the calls beyond the expected number of calls, which is never recovered by mocker
+type overCall string
+
 // NewMocker creates a new instance of mocker using the provided tester interface
 //
 //	tester simply pass in the Golang testing struct from a test method
-func NewMocker(tester testing.TB) Mocker {
+//	options pass in the options to customize the behaviors of the mocker, e.g. WithCopiedReturns()
+func NewMocker(tester testing.TB, options ...Option) Mocker {
 	var m = &mocker{
 		tester:  tester,
 		patches: gomonkey.NewPatches(),
 		entries: make(map[uintptr]*funcEntry),
 		locker:  &sync.Mutex{},
+		done:    make(chan struct{}),
+	}
+	for _, option := range options {
+		option(m)
 	}
+	m.options = options
 	m.tester.Cleanup(m.verifyAll)
 	m.tester.Helper()
 	return m
 }
 
-type parameter struct {
-	isAnything bool
-	matchFunc  func(value interface{}) bool
+type mismatch struct {
+	format string
+	args   []interface{}
+}
+
+type parameter interface {
+	compare(m *mocker, index int, actual reflect.Value) *mismatch
+}
+
+type anything struct{}
+
+func (p *anything) compare(m *mocker, index int, actual reflect.Value) *mismatch {
+	return nil
 }
 
 // Anything creates a parameter matcher that simply bypasses the check
-func Anything() *parameter {
-	return &parameter{
-		isAnything: true,
+func Anything() parameter {
+	return &anything{}
+}
+
+type matching struct {
+	matchFunc func(value interface{}) bool
+}
+
+func (p *matching) compare(m *mocker, index int, actual reflect.Value) (result *mismatch) {
+	defer func() {
+		var recovered = recover()
+		if recovered == nil {
+			return
+		}
+		var err, ok = recovered.(*runtime.TypeAssertionError)
+		if !ok {
+			panic(recovered)
+		}
+		result = &mismatch{
+			format: "matchFunc failed on actual %v of type %v: %v",
+			args:   []interface{}{actual.Interface(), reflect.TypeOf(actual.Interface()), err},
+		}
+	}()
+	if p.matchFunc(actual.Interface()) {
+		return nil
+	}
+	return &mismatch{
+		format: "matchFunc failed on actual %v",
+		args:   []interface{}{actual.Interface()},
 	}
 }
 
@@ -143,441 +770,3834 @@ func Anything() *parameter {
 //	matchFunc pass in the function that customizes the check for a particular parameter
 //	  the original parameter is wrapped into an interface and is given as `value` here
 //	  returning false would cause the corresponding test to fail
-func Matches(matchFunc func(value interface{}) bool) *parameter {
-	return &parameter{
+//	this is useful for types with unexported fields not meaningfully comparable by reflect.DeepEqual,
+//	  as the value is passed through as is, e.g. comparing time.Time using its Equal method
+//	a failed type assertion in matchFunc is reported as a parameter mismatch with both types, instead of a panic
+func Matches(matchFunc func(value interface{}) bool) parameter {
+	return &matching{
 		matchFunc: matchFunc,
 	}
 }
 
-type funcValue struct {
-	_ uintptr
-	p unsafe.Pointer
+// typedParameter is a parameter matcher only accepting the values of a particular type
+type typedParameter interface {
+	valueType() reflect.Type
 }
 
-func (m *mocker) getReflectPointer(value reflect.Value) uintptr {
-	m.tester.Helper()
-	return *(*uintptr)((*funcValue)(unsafe.Pointer(&value)).p)
+// acceptsType returns whether the values of the parameter type could possibly be accepted as the given value type
+func acceptsType(inType reflect.Type, valueType reflect.Type) bool {
+	if inType.AssignableTo(valueType) {
+		return true
+	}
+	return inType.Kind() == reflect.Interface && (valueType.Kind() == reflect.Interface || valueType.Implements(inType))
 }
 
-func (m *mocker) getFuncPointer(expectFunc interface{}) (uintptr, string) {
-	m.tester.Helper()
-	var value = reflect.ValueOf(expectFunc)
-	var funcPtr = m.getReflectPointer(value)
-	var pointer = value.Pointer()
-	var funcForPC = runtime.FuncForPC(pointer)
-	var name = funcForPC.Name()
-	var file, _ = funcForPC.FileLine(pointer)
-	return funcPtr, fmt.Sprint(file, ".", name)
+type matchingAt[T any] struct {
+	matchFunc func(index int, value T) bool
 }
 
-func (m *mocker) recover(name string) {
-	m.tester.Helper()
-	var result = recover()
-	if result == nil {
-		return
-	}
-	var message string
-	var err, ok = result.(error)
-	if ok {
-		message = err.Error()
-	} else {
-		message = fmt.Sprint(result)
-	}
-	m.tester.Errorf("[%v] Mocker panicing recovered: %v", name, message)
+func (p *matchingAt[T]) valueType() reflect.Type {
+	return reflect.TypeFor[T]()
 }
 
-func (m *mocker) doComparison(name string, calls int, index int, expect interface{}, actual reflect.Value) {
-	m.tester.Helper()
-	var param, ok = expect.(*parameter)
-	if !ok {
-		if expect == nil {
-			if actual.IsValid() && !actual.IsNil() {
-				m.tester.Errorf(
-					"[%v] Parameter mismatch at call #%v parameter #%v: expect %v, actual %v",
-					name,
-					calls,
-					index,
-					expect,
-					actual.Interface(),
-				)
+func (p *matchingAt[T]) compare(m *mocker, index int, actual reflect.Value) *mismatch {
+	var value T
+	if actual.IsValid() && actual.Interface() != nil {
+		var typed, ok = actual.Interface().(T)
+		if !ok {
+			return &mismatch{
+				format: "expect type %v, actual %v of type %v",
+				args:   []interface{}{reflect.TypeFor[T](), actual.Interface(), reflect.TypeOf(actual.Interface())},
 			}
-		} else if !reflect.DeepEqual(actual.Interface(), expect) {
-			m.tester.Errorf(
-				"[%v] Parameter mismatch at call #%v parameter #%v: expect %v, actual %v",
-				name,
-				calls,
-				index,
-				expect,
-				actual.Interface(),
-			)
 		}
-		return
+		value = typed
 	}
-	if param.isAnything {
-		return
+	if p.matchFunc(index, value) {
+		return nil
 	}
-	if param.matchFunc != nil {
-		if !param.matchFunc(actual.Interface()) {
-			m.tester.Errorf(
-				"[%v] Parameter mismatch at call #%v parameter #%v: matchFunc failed on actual %v",
-				name,
-				calls,
-				index,
-				actual.Interface(),
-			)
-		}
+	return &mismatch{
+		format: "matchFunc failed on actual %v",
+		args:   []interface{}{value},
 	}
 }
 
-func (m *mocker) compareNormalParameters(name string, calls int, expects []interface{}, actuals []reflect.Value) {
-	m.tester.Helper()
-	if len(expects) != len(actuals) {
-		m.tester.Errorf(
-			"[%v] Invalid number of parameters at call #%v: expect %v, actual %v",
-			name,
-			calls,
-			len(expects),
-			len(actuals),
-		)
-		return
-	}
-	for index, actual := range actuals {
-		m.doComparison(name, calls, index+1, expects[index], actual)
+// MatchesAt creates a parameter matcher using the provided position-aware match function
+//
+//	matchFunc pass in the function that customizes the check for a particular parameter
+//	  the 1-based position of the parameter is given as `index`, and the original parameter as `value` here
+//	  returning false would cause the corresponding test to fail
+//	the type T is verified against the type of the parameter when the setup completes
+func MatchesAt[T any](matchFunc func(index int, value T) bool) parameter {
+	return &matchingAt[T]{
+		matchFunc: matchFunc,
 	}
 }
 
-func (m *mocker) compareVariadicParameters(name string, calls int, expects []interface{}, actuals []reflect.Value) {
-	m.tester.Helper()
-	for index, actual := range actuals {
-		if index != len(actuals)-1 {
-			m.doComparison(name, calls, index+1, expects[index], actual)
-		} else {
-			if actual.Len() != len(expects)-index {
-				m.tester.Errorf(
-					"[%v] Invalid number of variadic parameters at call #%v: expect %v, actual %v",
-					name,
-					calls,
-					len(expects)-index,
-					actual.Len(),
-				)
-				return
-			}
-			for i := index; i < len(expects); i++ {
-				var expect = expects[i]
-				var item = actual.Index(i - index)
-				m.doComparison(name, calls, index+1, expect, item)
-			}
-		}
+type implementing struct {
+	target reflect.Type
+}
+
+func (p *implementing) compare(m *mocker, index int, actual reflect.Value) *mismatch {
+	var value interface{}
+	if actual.IsValid() {
+		value = actual.Interface()
+	}
+	var actualType = reflect.TypeOf(value)
+	if actualType != nil && actualType.AssignableTo(p.target) {
+		return nil
+	}
+	return &mismatch{
+		format: "expect value implementing %v, actual %v of type %v",
+		args:   []interface{}{p.target, value, actualType},
 	}
 }
 
-func (m *mocker) returnZeros(funcType reflect.Type) []reflect.Value {
-	var count = funcType.NumOut()
-	var rets = make([]reflect.Value, 0, count)
-	for i := 0; i < count; i++ {
-		rets = append(rets, reflect.Zero(funcType.Out(i)))
+// Implements creates a parameter matcher that checks the concrete type of the parameter implements the interface I
+//
+//	e.g. Implements[io.Reader]() matches a *bytes.Buffer but not an int
+func Implements[I any]() parameter {
+	return &implementing{
+		target: reflect.TypeFor[I](),
 	}
-	return rets
 }
 
-func (m *mocker) constructReturns(name string, calls int, funcType reflect.Type, returns []interface{}) []reflect.Value {
-	m.tester.Helper()
-	var count = funcType.NumOut()
-	if count != len(returns) {
-		m.tester.Errorf(
-			"[%v] Invalid number of returns at call #%v: expect %v, actual %v",
+type someError struct{}
+
+func (p *someError) compare(m *mocker, index int, actual reflect.Value) *mismatch {
+	var value interface{}
+	if actual.IsValid() {
+		value = actual.Interface()
+	}
+	var err, ok = value.(error)
+	if !ok && value != nil {
+		return &mismatch{
+			format: "expect a non-nil error, actual %v of non-error type %v",
+			args:   []interface{}{value, reflect.TypeOf(value)},
+		}
+	}
+	if err == nil || (reflect.ValueOf(err).Kind() == reflect.Pointer && reflect.ValueOf(err).IsNil()) {
+		return &mismatch{
+			format: "expect a non-nil error, actual %v",
+			args:   []interface{}{value},
+		}
+	}
+	return nil
+}
+
+// SomeError creates a parameter matcher that checks the parameter is a non-nil error of any kind
+//
+//	a nil pointer wrapped into an error is considered nil, as it is almost always a mistake
+func SomeError() parameter {
+	return &someError{}
+}
+
+type timeWithin struct {
+	expected  time.Time
+	tolerance time.Duration
+}
+
+func (p *timeWithin) compare(m *mocker, index int, actual reflect.Value) *mismatch {
+	var value interface{}
+	if actual.IsValid() {
+		value = actual.Interface()
+	}
+	if pointer, ok := value.(*time.Time); ok && pointer != nil {
+		value = *pointer
+	}
+	var timestamp, ok = value.(time.Time)
+	if !ok {
+		return &mismatch{
+			format: "expect time within %v of %v, actual %v of type %v",
+			args:   []interface{}{p.tolerance, p.expected, value, reflect.TypeOf(value)},
+		}
+	}
+	var difference = timestamp.Sub(p.expected)
+	if difference < 0 {
+		difference = -difference
+	}
+	if difference <= p.tolerance {
+		return nil
+	}
+	return &mismatch{
+		format: "expect time within %v of %v, actual %v off by %v",
+		args:   []interface{}{p.tolerance, p.expected, timestamp, difference},
+	}
+}
+
+// TimeWithin creates a parameter matcher that checks the parameter is a time.Time close to the expected one
+//
+//	expected pass in the expected time, and tolerance the maximum difference allowed either way
+//	  a non-nil *time.Time parameter is dereferenced before the check
+func TimeWithin(expected time.Time, tolerance time.Duration) parameter {
+	return &timeWithin{
+		expected:  expected,
+		tolerance: tolerance,
+	}
+}
+
+type floatNear struct {
+	expected float64
+	epsilon  float64
+	nan      bool
+}
+
+func (p *floatNear) compare(m *mocker, index int, actual reflect.Value) *mismatch {
+	if actual.Kind() == reflect.Interface {
+		actual = actual.Elem()
+	}
+	if !actual.IsValid() || (actual.Kind() != reflect.Float32 && actual.Kind() != reflect.Float64) {
+		var value interface{}
+		if actual.IsValid() {
+			value = actual.Interface()
+		}
+		return &mismatch{
+			format: "expect float within %v of %v, actual %v of type %v",
+			args:   []interface{}{p.epsilon, p.expected, value, reflect.TypeOf(value)},
+		}
+	}
+	var value = actual.Float()
+	if math.IsNaN(value) || math.IsNaN(p.expected) {
+		if p.nan && math.IsNaN(value) && math.IsNaN(p.expected) {
+			return nil
+		}
+		return &mismatch{
+			format: "expect float within %v of %v, actual %v",
+			args:   []interface{}{p.epsilon, p.expected, value},
+		}
+	}
+	var difference = math.Abs(value - p.expected)
+	if difference <= p.epsilon {
+		return nil
+	}
+	return &mismatch{
+		format: "expect float within %v of %v, actual %v off by %v",
+		args:   []interface{}{p.epsilon, p.expected, value, difference},
+	}
+}
+
+// FloatNear creates a parameter matcher that checks the parameter is a float32 or float64 close to the expected one
+//
+//	expected pass in the expected value, and epsilon the maximum difference allowed either way
+//	  NaN never matches, even if the expected value is NaN; use FloatNearOrNaN to match NaN against NaN
+func FloatNear(expected float64, epsilon float64) parameter {
+	return &floatNear{
+		expected: expected,
+		epsilon:  epsilon,
+	}
+}
+
+// FloatNearOrNaN creates a parameter matcher same as FloatNear, except a NaN parameter matches a NaN expected value
+func FloatNearOrNaN(expected float64, epsilon float64) parameter {
+	return &floatNear{
+		expected: expected,
+		epsilon:  epsilon,
+		nan:      true,
+	}
+}
+
+type mapContaining[K comparable, V any] struct {
+	subset map[K]V
+}
+
+func (p *mapContaining[K, V]) compare(m *mocker, index int, actual reflect.Value) *mismatch {
+	var value interface{}
+	if actual.IsValid() {
+		value = actual.Interface()
+	}
+	var typed, ok = value.(map[K]V)
+	if !ok {
+		return &mismatch{
+			format: "expect map containing %v, actual %v of type %v",
+			args:   []interface{}{p.subset, value, reflect.TypeOf(value)},
+		}
+	}
+	var keys = make([]K, 0, len(p.subset))
+	for key := range p.subset {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b K) int {
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	})
+	for _, key := range keys {
+		var item, found = typed[key]
+		if !found {
+			return &mismatch{
+				format: "expect map containing key %v, actual %v",
+				args:   []interface{}{key, value},
+			}
+		}
+		if !reflect.DeepEqual(item, p.subset[key]) {
+			return &mismatch{
+				format: "expect %v for key %v, actual %v",
+				args:   []interface{}{p.subset[key], key, item},
+			}
+		}
+	}
+	return nil
+}
+
+// MapContains creates a parameter matcher that checks the parameter is a map containing all entries of the subset
+//
+//	the extra entries in the parameter are ignored, while the first missing or different key in order is reported
+func MapContains[K comparable, V any](subset map[K]V) parameter {
+	return &mapContaining[K, V]{
+		subset: subset,
+	}
+}
+
+type fieldsMatching struct {
+	expected interface{}
+	fields   []string
+}
+
+func (p *fieldsMatching) compare(m *mocker, index int, actual reflect.Value) *mismatch {
+	var expected = reflect.Indirect(reflect.ValueOf(p.expected))
+	for actual.IsValid() && (actual.Kind() == reflect.Interface || actual.Kind() == reflect.Pointer) && !actual.IsNil() {
+		actual = actual.Elem()
+	}
+	if !actual.IsValid() || actual.Kind() != reflect.Struct || expected.Kind() != reflect.Struct || actual.Type() != expected.Type() {
+		var value interface{}
+		if actual.IsValid() && actual.CanInterface() {
+			value = actual.Interface()
+		}
+		return &mismatch{
+			format: "expect struct of type %v, actual %v of type %v",
+			args:   []interface{}{reflect.TypeOf(p.expected), value, reflect.TypeOf(value)},
+		}
+	}
+	for _, name := range p.fields {
+		var field, found = expected.Type().FieldByName(name)
+		if !found || !field.IsExported() {
+			return &mismatch{
+				format: "expect exported field %v in struct of type %v, actual none",
+				args:   []interface{}{name, expected.Type()},
+			}
+		}
+		var expect = expected.FieldByIndex(field.Index).Interface()
+		var value = actual.FieldByIndex(field.Index).Interface()
+		if !reflect.DeepEqual(expect, value) {
+			return &mismatch{
+				format: "expect field %v to be %v, actual %v",
+				args:   []interface{}{name, renderValue(expect), renderValue(value)},
+			}
+		}
+	}
+	return nil
+}
+
+// FieldsMatch creates a parameter matcher that checks only the named fields of a struct against the expected struct
+//
+//	this is useful to check a few relevant fields of a big struct, while ignoring the rest of the fields
+//
+//	expected pass in the expected struct, or the pointer to it, of the same type as the parameter
+//	fields pass in the names of the exported fields to be checked, where the first different one is reported
+func FieldsMatch(expected any, fields ...string) parameter {
+	return &fieldsMatching{
+		expected: expected,
+		fields:   fields,
+	}
+}
+
+type jsonPath struct {
+	path  string
+	inner parameter
+}
+
+func (p *jsonPath) compare(m *mocker, index int, actual reflect.Value) *mismatch {
+	var value interface{}
+	if actual.IsValid() {
+		value = actual.Interface()
+	}
+	var data, err = json.Marshal(value)
+	if err != nil {
+		return &mismatch{
+			format: "expect JSON path %v, actual %v not marshalable to JSON: %v",
+			args:   []interface{}{p.path, value, err},
+		}
+	}
+	var current interface{}
+	_ = json.Unmarshal(data, &current)
+	for _, segment := range strings.Split(p.path, ".") {
+		var found = false
+		switch node := current.(type) {
+		case map[string]interface{}:
+			current, found = node[segment]
+		case []interface{}:
+			var position, err = strconv.Atoi(segment)
+			if err == nil && position >= 0 && position < len(node) {
+				current, found = node[position], true
+			}
+		}
+		if !found {
+			return &mismatch{
+				format: "expect JSON path %v, actual %s missing segment %v",
+				args:   []interface{}{p.path, data, segment},
+			}
+		}
+	}
+	var result = p.inner.compare(m, index, reflect.ValueOf(current))
+	if result == nil {
+		return nil
+	}
+	return &mismatch{
+		format: "at JSON path %v: " + result.format,
+		args:   append([]interface{}{p.path}, result.args...),
+	}
+}
+
+// JSONPath creates a parameter matcher that checks the value at the given path of the parameter marshaled to JSON
+//
+//	this is useful to check one nested field of a complex struct without reconstructing the whole expected struct
+//
+//	path pass in the dotted path of JSON keys, e.g. `user.id`, where a numeric segment indexes into an array
+//	inner pass in the parameter matcher to check the extracted value with
+//	  note that the extracted value is decoded from JSON, thus numbers are float64, objects are map[string]any, etc.
+func JSONPath(path string, inner parameter) parameter {
+	return &jsonPath{
+		path:  path,
+		inner: inner,
+	}
+}
+
+type validJSON struct{}
+
+func (p *validJSON) compare(m *mocker, index int, actual reflect.Value) *mismatch {
+	for actual.IsValid() && actual.Kind() == reflect.Interface {
+		actual = actual.Elem()
+	}
+	var data []byte
+	switch {
+	case actual.IsValid() && actual.Kind() == reflect.String:
+		data = []byte(actual.String())
+	case actual.IsValid() && actual.Kind() == reflect.Slice && actual.Type().Elem().Kind() == reflect.Uint8:
+		data = actual.Bytes()
+	case actual.IsValid():
+		return &mismatch{
+			format: "expect valid JSON, actual %v of non-string type %v",
+			args:   []interface{}{actual.Interface(), actual.Type()},
+		}
+	default:
+		return &mismatch{
+			format: "expect valid JSON, actual %v",
+			args:   []interface{}{nil},
+		}
+	}
+	if !json.Valid(data) {
+		return &mismatch{
+			format: "expect valid JSON, actual %q",
+			args:   []interface{}{data},
+		}
+	}
+	return nil
+}
+
+// ValidJSON creates a parameter matcher that checks the parameter is a string or []byte of syntactically valid JSON
+//
+//	this is useful to check a serialization layer produced parseable output, regardless of its exact content
+func ValidJSON() parameter {
+	return &validJSON{}
+}
+
+type counting struct {
+	inner interface{}
+	count atomic.Int64
+}
+
+func (p *counting) compare(m *mocker, index int, actual reflect.Value) *mismatch {
+	var result = m.compareParameter(p.inner, index, actual)
+	if result == nil {
+		p.count.Add(1)
+	}
+	return result
+}
+
+// Count returns the number of calls whose argument satisfied the inner matcher so far
+func (p *counting) Count() int {
+	return int(p.count.Load())
+}
+
+// CountingMatcher creates a parameter matcher that records the number of times it matched
+//
+//	inner pass in the expected value or parameter matcher to delegate the check to
+//	  only the calls whose argument satisfied inner are counted, unlike the call count of the mock itself
+func CountingMatcher(inner interface{}) *counting {
+	return &counting{
+		inner: inner,
+	}
+}
+
+type elementsMatching struct {
+	expected interface{}
+}
+
+func (p *elementsMatching) compare(m *mocker, index int, actual reflect.Value) *mismatch {
+	var expected = reflect.ValueOf(p.expected)
+	if !isListValue(expected) || !isListValue(actual) {
+		return &mismatch{
+			format: "expect elements of %v, actual %v",
+			args:   []interface{}{p.expected, actual.Interface()},
+		}
+	}
+	var missing = []interface{}{}
+	for i := 0; i < expected.Len(); i++ {
+		missing = append(missing, expected.Index(i).Interface())
+	}
+	var extra = []interface{}{}
+	for i := 0; i < actual.Len(); i++ {
+		var item = actual.Index(i).Interface()
+		var found = false
+		for j, expect := range missing {
+			if reflect.DeepEqual(expect, item) {
+				missing = append(missing[:j], missing[j+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			extra = append(extra, item)
+		}
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return nil
+	}
+	return &mismatch{
+		format: "elements mismatch, missing %v, extra %v",
+		args:   []interface{}{missing, extra},
+	}
+}
+
+func isListValue(value reflect.Value) bool {
+	return value.IsValid() && (value.Kind() == reflect.Slice || value.Kind() == reflect.Array)
+}
+
+// ElementsMatch creates a parameter matcher that checks a slice or array regardless of the order of elements
+//
+//	expected pass in the slice or array containing the expected elements
+//	  the actual parameter must contain the same elements for the same number of times as expected
+func ElementsMatch(expected any) parameter {
+	return &elementsMatching{
+		expected: expected,
+	}
+}
+
+// codeSize is the number of leading bytes of machine code compared to detect whether a patch took effect
+const codeSize = 16
+
+type funcValue struct {
+	_ uintptr
+	p unsafe.Pointer
+}
+
+func (m *mocker) getReflectPointer(value reflect.Value) uintptr {
+	m.tester.Helper()
+	return *(*uintptr)((*funcValue)(unsafe.Pointer(&value)).p)
+}
+
+// getMethod resolves a method of the receiver type by name, reporting the failure if it cannot be resolved
+//
+//	exported methods are resolved through reflect, while unexported ones through creflect of gomonkey,
+//	  which finds their code but not their types, thus the signature given is used as the type of the method
+func (m *mocker) getMethod(target interface{}, methodName string, signature []interface{}) (reflect.Value, bool) {
+	m.tester.Helper()
+	var receiver = reflect.TypeOf(target)
+	if receiver == nil {
+		m.fatalf(
+			"The method [%v] of type [%T] cannot be found",
+			methodName,
+			target,
+		)
+		return reflect.Value{}, false
+	}
+	if token.IsExported(methodName) {
+		var method, found = receiver.MethodByName(methodName)
+		if !found {
+			m.fatalf(
+				"The method [%v] of type [%T] cannot be found",
+				methodName,
+				target,
+			)
+			return reflect.Value{}, false
+		}
+		if len(signature) > 0 && (len(signature) > 1 || reflect.TypeOf(signature[0]) != method.Type) {
+			m.fatalf(
+				"Unexpected signature %v passed for the method [%v] of type [%T], which is of type %v",
+				signature,
+				methodName,
+				target,
+				method.Type,
+			)
+			return reflect.Value{}, false
+		}
+		return method.Func, true
+	}
+	var code, found = creflect.MethodByName(receiver, methodName)
+	if !found {
+		m.fatalf(
+			"The method [%v] of type [%T] cannot be found",
+			methodName,
+			target,
+		)
+		return reflect.Value{}, false
+	}
+	var funcType reflect.Type
+	if len(signature) == 1 {
+		funcType = reflect.TypeOf(signature[0])
+	}
+	if funcType == nil || funcType.Kind() != reflect.Func || funcType.NumIn() == 0 || funcType.In(0) != receiver {
+		m.fatalf(
+			"Unexpected signature %v passed for the unexported method [%v] of type [%T], which must be given"+
+				" as a single function value taking the receiver as the first parameter, e.g. (func(*foo, string) error)(nil)",
+			signature,
+			methodName,
+			target,
+		)
+		return reflect.Value{}, false
+	}
+	var method = reflect.New(funcType)
+	*(*unsafe.Pointer)(method.UnsafePointer()) = code
+	return reflect.ValueOf(method.Elem().Interface()), true
+}
+
+func (m *mocker) getCodeBytes(value reflect.Value) [codeSize]byte {
+	m.tester.Helper()
+	return *(*[codeSize]byte)(*(*unsafe.Pointer)((*funcValue)(unsafe.Pointer(&value)).p))
+}
+
+// patchOwners keeps the mockers currently patching each function pointer across all tests of the process
+//
+//	the mockers of each function pointer are kept in the order of their first patches, and sequence numbers them
+var patchOwners = struct {
+	sync.Mutex
+	mockers  map[uintptr][]*mocker
+	sequence int
+}{
+	mockers: make(map[uintptr][]*mocker),
+}
+
+// claimPatch registers the current mocker as an owner of the target, failing the test if another test already owns it
+//
+//	conflicts are only detected by target, thus parallel tests must not patch the same functions or methods,
+//	while the parallel tests patching different ones are safe as usual
+func (m *mocker) claimPatch(name string, key uintptr) bool {
+	m.tester.Helper()
+	patchOwners.Lock()
+	defer patchOwners.Unlock()
+	var owners = patchOwners.mockers[key]
+	if slices.Contains(owners, m) {
+		return true
+	}
+	var test = m.tester.Name()
+	for _, owner := range owners {
+		var other = owner.tester.Name()
+		if other != test && !strings.HasPrefix(test, other+"/") {
+			m.fatalf(
+				"The function or method [%v] is already patched by test [%v] while test [%v] tries to patch it."+
+					" Monkey patching is process-global, thus incompatible with t.Parallel for overlapping targets;"+
+					" avoid calling t.Parallel in tests patching the same functions or methods.",
+				name,
+				other,
+				test,
+			)
+			return false
+		}
+	}
+	if m.applied == 0 {
+		patchOwners.sequence++
+		m.applied = patchOwners.sequence
+	}
+	patchOwners.mockers[key] = append(owners, m)
+	m.claims = append(m.claims, key)
+	return true
+}
+
+func (m *mocker) releasePatches() {
+	patchOwners.Lock()
+	defer patchOwners.Unlock()
+	for _, key := range m.claims {
+		var owners = slices.DeleteFunc(patchOwners.mockers[key], func(owner *mocker) bool {
+			return owner == m
+		})
+		if len(owners) == 0 {
+			delete(patchOwners.mockers, key)
+		} else {
+			patchOwners.mockers[key] = owners
+		}
+	}
+	m.claims = nil
+}
+
+// laterOwners returns the mockers patching the same targets after the current one, directly or transitively
+//
+//	the mockers are returned in the reverse order of their first patches, i.e. the order to reset them
+func (m *mocker) laterOwners() []*mocker {
+	patchOwners.Lock()
+	defer patchOwners.Unlock()
+	var laters []*mocker
+	var pending = []*mocker{m}
+	for len(pending) > 0 {
+		var current = pending[0]
+		pending = pending[1:]
+		for _, key := range current.claims {
+			var owners = patchOwners.mockers[key]
+			for _, owner := range owners[slices.Index(owners, current)+1:] {
+				if owner != m && !slices.Contains(laters, owner) {
+					laters = append(laters, owner)
+					pending = append(pending, owner)
+				}
+			}
+		}
+	}
+	slices.SortFunc(laters, func(a *mocker, b *mocker) int {
+		return b.applied - a.applied
+	})
+	return laters
+}
+
+// resetPatches resets the patches of the current mocker, failing the test if later mockers still patch the same targets
+//
+//	patches must be reset in the reverse order of their application, otherwise a stale patch could be restored,
+//	thus the later mockers are reset first, and the test fails since their calls reach the real functions from then on
+func (m *mocker) resetPatches() {
+	m.tester.Helper()
+	var laters = m.laterOwners()
+	var tests = make([]string, 0, len(laters))
+	for _, later := range laters {
+		later.locker.Lock()
+		later.patches.Reset()
+		later.restoreVariables()
+		later.locker.Unlock()
+		later.releasePatches()
+		tests = append(tests, later.tester.Name())
+	}
+	m.patches.Reset()
+	m.restoreVariables()
+	m.releasePatches()
+	if len(laters) > 0 {
+		m.fatalf(
+			"The mocker of test [%v] is reset while %v later mocker(s) of tests %v still patch the same functions or methods,"+
+				" thus they are reset as well and call into the real functions from now on."+
+				" Reset or close the mockers in the reverse order of their creation.",
+			m.tester.Name(),
+			len(laters),
+			tests,
+		)
+	}
+}
+
+// restoreVariables restores the variables patched by MockVar or MockFuncVar to their original values in the reverse order of patching
+func (m *mocker) restoreVariables() {
+	for _, variable := range slices.Backward(m.variables) {
+		variable.target.Set(variable.original)
+	}
+	m.variables = nil
+}
+
+func (m *mocker) applyPatch(name string, target reflect.Value, double reflect.Value) {
+	m.tester.Helper()
+	m.patchCode(name, target, func() {
+		m.patches.ApplyCore(target, double)
+	})
+}
+
+// applyPrivatePatch patches the unexported method with the double through ApplyPrivateMethod of gomonkey
+func (m *mocker) applyPrivatePatch(name string, target reflect.Value, receiver reflect.Type, methodName string, double reflect.Value) {
+	m.tester.Helper()
+	m.patchCode(name, target, func() {
+		m.patches.ApplyPrivateMethod(receiver, methodName, double.Interface())
+	})
+}
+
+// patchCode claims the target and applies the patch, reporting the failure if the code of the target is left unchanged
+func (m *mocker) patchCode(name string, target reflect.Value, apply func()) {
+	m.tester.Helper()
+	if !m.claimPatch(name, m.getReflectPointer(target)) {
+		return
+	}
+	var before = m.getCodeBytes(target)
+	apply()
+	if m.getCodeBytes(target) == before {
+		m.fatalf(
+			"The underlying function or method [%v] was not patched, thus calls to it would not reach the mocker."+
+				" Try adding //go:noinline to it, or running tests with -gcflags=all=-l to disable optimizations.",
 			name,
-			calls,
+		)
+	}
+}
+
+// applyVarPatch patches the function variable with the double, after claiming the variable as any other target
+func (m *mocker) applyVarPatch(name string, target interface{}, double reflect.Value) {
+	m.tester.Helper()
+	if !m.claimPatch(name, reflect.ValueOf(target).Pointer()) {
+		return
+	}
+	m.patchVariable(reflect.ValueOf(target).Elem(), double)
+}
+
+// patchVariable sets the variable to the replacement, saving its original value when the variable is first patched
+//
+//	MockVar and MockFuncVar share the saved values, thus the variable is restored to its value before either patched it
+func (m *mocker) patchVariable(variable reflect.Value, replacement reflect.Value) {
+	if !slices.ContainsFunc(m.variables, func(patched *patchedVariable) bool {
+		return patched.target.Addr().Pointer() == variable.Addr().Pointer()
+	}) {
+		var original = reflect.New(variable.Type()).Elem()
+		original.Set(variable)
+		m.variables = append(m.variables, &patchedVariable{target: variable, original: original})
+	}
+	variable.Set(replacement)
+}
+
+func (m *mocker) getFuncPointer(expectFunc interface{}) (uintptr, string) {
+	m.tester.Helper()
+	var value = reflect.ValueOf(expectFunc)
+	var funcPtr = m.getReflectPointer(value)
+	var pointer = value.Pointer()
+	var funcForPC = runtime.FuncForPC(pointer)
+	var name = funcForPC.Name()
+	var file, _ = funcForPC.FileLine(pointer)
+	return funcPtr, fmt.Sprint(filepath.Base(file), ".", name)
+}
+
+// verifyInstantiation reports an instantiation of a generic function or method, e.g. Lookup[string]
+//
+//	the value passed in is a wrapper per instantiation, while the direct calls go to the code shared by all instantiations
+//	of the same shape with a dictionary of the type arguments, thus patching either could not tell the instantiations apart
+func (m *mocker) verifyInstantiation(name string, expectFunc interface{}) bool {
+	m.tester.Helper()
+	if !strings.Contains(name, "[...]") {
+		return true
+	}
+	m.fatalf(
+		"The function or method [%v] of type %v is an instantiation of a generic function or method, which cannot be mocked"+
+			" as its direct calls run the code shared by all instantiations of the same shape rather than the value passed in."+
+			" Try assigning the instantiation to a function variable used by the code, e.g. `var lookup = Lookup[string]`, and MockFuncVar instead.",
+		strings.ReplaceAll(name, "[...]", "[T]"),
+		reflect.TypeOf(expectFunc),
+	)
+	return false
+}
+
+// verifyTarget reports a bound method value, e.g. obj.Foo, which is a wrapper never called by the calls to the method
+//
+//	patching the wrapper would silently intercept nothing, thus the method expression, e.g. (*foo).Foo, must be used
+func (m *mocker) verifyTarget(name string) bool {
+	m.tester.Helper()
+	if !strings.HasSuffix(name, "-fm") {
+		return true
+	}
+	m.fatalf(
+		"The function or method [%v] is a bound method value, which cannot be mocked as calls to the method never go through it."+
+			" Try using the method expression instead, e.g. (*foo).Foo rather than obj.Foo, or MockOn for a particular instance.",
+		name,
+	)
+	return false
+}
+
+// timelineSize is the maximum number of latest calls kept in the timeline reported on failures
+const timelineSize = 100
+
+// argumentSize is the maximum number of characters rendered for each argument in the timeline
+const argumentSize = 32
+
+func (m *mocker) errorf(format string, args ...interface{}) {
+	m.tester.Helper()
+	m.failed.Store(true)
+	m.tester.Errorf(format, args...)
+}
+
+func (m *mocker) fatalf(format string, args ...interface{}) {
+	m.tester.Helper()
+	m.failed.Store(true)
+	m.aborted.Store(true)
+	m.tester.Fatalf(format, args...)
+}
+
+func renderArguments(args []reflect.Value) string {
+	var texts = make([]string, 0, len(args))
+	for _, arg := range args {
+		texts = append(texts, renderArgument(arg))
+	}
+	return strings.Join(texts, ", ")
+}
+
+// renderArgument renders the argument cut off at argumentSize characters
+//
+//	strings and slices are cut before formatting, thus a huge payload is never formatted in full,
+//	  unless its type customizes the formatting, e.g. through a String method
+func renderArgument(arg reflect.Value) string {
+	var value = arg.Interface()
+	var _, stringer = value.(fmt.Stringer)
+	var _, err = value.(error)
+	if !stringer && !err {
+		switch arg.Kind() {
+		case reflect.String:
+			var text = arg.String()
+			value = text[:runeBoundary(text, min(len(text), (argumentSize+1)*utf8.UTFMax))]
+		case reflect.Slice:
+			value = arg.Slice(0, min(arg.Len(), argumentSize+1)).Interface()
+		}
+	}
+	var text = []rune(fmt.Sprintf("%v", value))
+	if len(text) > argumentSize {
+		text = append(text[:argumentSize], []rune("...")...)
+	}
+	return string(text)
+}
+
+func (e *funcEntry) renderArguments(args []reflect.Value) string {
+	if e.formatter == nil {
+		return renderArguments(args)
+	}
+	var texts = make([]string, 0, len(args))
+	for _, arg := range args {
+		texts = append(texts, e.formatter(arg.Interface()))
+	}
+	return strings.Join(texts, ", ")
+}
+
+// record appends the call to the timeline, which must be called while holding the locker
+//
+//	the arguments are rendered by the caller before acquiring the locker, as rendering may run their String methods
+func (m *mocker) record(name string, calls int, args string) {
+	m.tester.Helper()
+	m.calls++
+	if len(m.timeline) == timelineSize {
+		m.timeline = m.timeline[1:]
+	}
+	m.timeline = append(m.timeline, &callRecord{
+		sequence: m.calls,
+		name:     name,
+		calls:    calls,
+		args:     args,
+	})
+}
+
+func (m *mocker) logCall(name string, calls int, args []reflect.Value, mock *mockEntry, results []reflect.Value) {
+	m.tester.Helper()
+	if !m.debug {
+		return
+	}
+	var handler = "no setup"
+	if mock != nil {
+		handler = "setup"
+		if mock.declared != "" {
+			handler = "setup declared at " + mock.declared
+		}
+	}
+	m.tester.Logf(
+		"[%v] call #%v (%v) handled by %v, returning (%v)",
+		name,
+		calls,
+		renderArguments(args),
+		handler,
+		renderArguments(results),
+	)
+}
+
+func (m *mocker) reportTimeline() {
+	m.tester.Helper()
+	if !m.failed.Load() || len(m.timeline) == 0 {
+		return
+	}
+	var lines = make([]string, 0, len(m.timeline))
+	for _, record := range m.timeline {
+		lines = append(lines, fmt.Sprintf(
+			"#%v [%v] call #%v (%v)",
+			record.sequence,
+			record.name,
+			record.calls,
+			record.args,
+		))
+	}
+	m.tester.Logf(
+		"Call timeline of the latest %v calls:\n%v",
+		len(lines),
+		strings.Join(lines, "\n"),
+	)
+}
+
+func (m *mocker) recover(name string) {
+	m.tester.Helper()
+	var result = recover()
+	if result == nil {
+		return
+	}
+	if call, ok := result.(overCall); ok {
+		panic(call)
+	}
+	var message string
+	var err, ok = result.(error)
+	if ok {
+		message = err.Error()
+	} else {
+		message = fmt.Sprint(result)
+	}
+	if !m.stack {
+		m.errorf("[%v] Mocker panicing recovered: %v", name, message)
+		return
+	}
+	var stack = debug.Stack()
+	if len(stack) > stackSize {
+		stack = append(stack[:stackSize], "..."...)
+	}
+	m.errorf("[%v] Mocker panicing recovered: %v\n%s", name, message, stack)
+}
+
+// stackSize is the maximum number of bytes of the stack trace rendered for a recovered panic
+const stackSize = 8192
+
+func (m *mocker) compareParameter(expect interface{}, index int, actual reflect.Value) *mismatch {
+	var param, ok = expect.(parameter)
+	if ok {
+		return param.compare(m, index, actual)
+	}
+	if expect == nil {
+		if actual.IsValid() && !actual.IsNil() && !(m.nilEmpty && isNilOrEmpty(actual)) {
+			return &mismatch{
+				format: "expect %v, actual %v",
+				args:   []interface{}{expect, actual.Interface()},
+			}
+		}
+		return nil
+	}
+	var value = reflect.ValueOf(expect)
+	if value.Kind() == reflect.Chan && actual.Kind() == reflect.Chan && value.Type().AssignableTo(actual.Type()) {
+		expect = value.Convert(actual.Type()).Interface()
+	}
+	if m.nilEmpty && reflect.TypeOf(expect) == reflect.TypeOf(actual.Interface()) && isNilOrEmpty(value) && isNilOrEmpty(actual) {
+		return nil
+	}
+	if !reflect.DeepEqual(actual.Interface(), expect) {
+		var expectType, actualType = reflect.TypeOf(expect), reflect.TypeOf(actual.Interface())
+		if expectType != actualType {
+			return &mismatch{
+				format: "expect %v (%v), actual %v (%v)",
+				args:   []interface{}{renderValue(expect), expectType, renderValue(actual.Interface()), actualType},
+			}
+		}
+		return &mismatch{
+			format: "expect %v, actual %v",
+			args:   []interface{}{renderValue(expect), renderValue(actual.Interface())},
+		}
+	}
+	return nil
+}
+
+// isNilOrEmpty checks whether the value is a nil or empty slice or map, looking through the interfaces holding it
+func isNilOrEmpty(value reflect.Value) bool {
+	for value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	return (value.Kind() == reflect.Slice || value.Kind() == reflect.Map) && value.Len() == 0
+}
+
+// renderValue renders a struct value with its field names, leaving other values to be rendered as is
+func renderValue(value interface{}) interface{} {
+	if reflect.ValueOf(value).Kind() != reflect.Struct {
+		return value
+	}
+	return fmt.Sprintf("%#v", value)
+}
+
+func (m *mocker) doComparison(entry *funcEntry, index int, expect interface{}, actual reflect.Value) *mismatch {
+	var result = m.compareParameter(expect, index, actual)
+	if result == nil {
+		return nil
+	}
+	var values = result.args
+	if entry.formatter != nil {
+		values = make([]interface{}, 0, len(result.args))
+		for _, value := range result.args {
+			values = append(values, entry.formatter(value))
+		}
+	} else if !m.verbose {
+		values = make([]interface{}, 0, len(result.args))
+		for _, value := range result.args {
+			values = append(values, boundValue(value))
+		}
+	}
+	return &mismatch{
+		format: "parameter #%v: " + result.format,
+		args:   append([]interface{}{index}, values...),
+	}
+}
+
+// reportMismatches reports all parameter mismatches of a call as one failure
+//
+//	a single mismatch is reported on one line, while multiple mismatches are listed one per line
+//	  after a summary of how many parameters matched, which helps to spot shifted arguments
+func (m *mocker) reportMismatches(name string, calls int, entry *funcEntry, mock *mockEntry, args []reflect.Value, total int, results []*mismatch) {
+	m.tester.Helper()
+	if len(results) == 0 {
+		return
+	}
+	var result = &mismatch{
+		format: "[%v] Parameter mismatch at call #%v " + results[0].format,
+		args:   append([]interface{}{name, calls}, results[0].args...),
+	}
+	if len(results) > 1 {
+		result = &mismatch{
+			format: "[%v] Parameter mismatch at call #%v: %v of %v parameters matched",
+			args:   []interface{}{name, calls, total - len(results), total},
+		}
+		for _, item := range results {
+			result.format += "\n\t" + item.format
+			result.args = append(result.args, item.args...)
+		}
+	}
+	result = withDeclared(result, mock.declared)
+	result = m.withClosest(result, calls, entry, mock, args)
+	if m.fatal {
+		m.fatalf(result.format, result.args...)
+		return
+	}
+	m.errorf(result.format, result.args...)
+}
+
+// withClosest appends a hint of the not yet consumed setup matching the arguments of a mismatched call
+//
+//	a mismatch is often caused by calls arriving in a different order than the setups, rather than wrong arguments
+func (m *mocker) withClosest(result *mismatch, calls int, entry *funcEntry, mock *mockEntry, args []reflect.Value) *mismatch {
+	for index := calls; index < len(entry.mocks); index++ {
+		var candidate = entry.mocks[index]
+		if candidate == mock || candidate.anyOrder || !m.matchParameters(entry, candidate.parameters, args) {
+			continue
+		}
+		if candidate.declared == "" {
+			return &mismatch{
+				format: result.format + "\nnote: arguments match the expectation for call #%v",
+				args:   append(result.args, index+1),
+			}
+		}
+		return &mismatch{
+			format: result.format + "\nnote: arguments match the expectation declared at %v (call #%v)",
+			args:   append(result.args, candidate.declared, index+1),
+		}
+	}
+	return result
+}
+
+// valueSize is the maximum number of characters rendered for each value in failure messages
+const valueSize = 256
+
+// hexdumpSize is the number of leading bytes rendered for a byte slice exceeding valueSize
+const hexdumpSize = 32
+
+func boundValue(value interface{}) interface{} {
+	var text = fmt.Sprint(value)
+	if len(text) <= valueSize {
+		return value
+	}
+	switch typed := value.(type) {
+	case []byte:
+		return fmt.Sprintf("[]byte(len=%v) %x...", len(typed), typed[:hexdumpSize])
+	case string:
+		return fmt.Sprintf("%v...(len=%v)", typed[:runeBoundary(typed, valueSize)], len(typed))
+	}
+	return text[:runeBoundary(text, valueSize)] + "..."
+}
+
+// runeBoundary returns the largest index up to size where the text can be cut without splitting a multi-byte rune
+func runeBoundary(text string, size int) int {
+	for size > 0 && size < len(text) && !utf8.RuneStart(text[size]) {
+		size--
+	}
+	return size
+}
+
+func withDeclared(result *mismatch, declared string) *mismatch {
+	if declared == "" {
+		return result
+	}
+	return &mismatch{
+		format: result.format + " (declared at %v)",
+		args:   append(result.args, declared),
+	}
+}
+
+func (m *mocker) callerInfo() []string {
+	if m.callers == 0 {
+		return nil
+	}
+	var pcs = make([]uintptr, 64)
+	var frames = runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	var callers = []string{}
+	for len(callers) < m.callers {
+		var frame, more = frames.Next()
+		if frame.File != sourceFile &&
+			!strings.HasPrefix(frame.Function, "reflect.") &&
+			!strings.HasPrefix(frame.Function, "runtime.") &&
+			!strings.HasPrefix(frame.Function, "github.com/agiledragon/gomonkey") {
+			callers = append(callers, fmt.Sprint(filepath.Base(frame.File), ":", frame.Line))
+		}
+		if !more {
+			break
+		}
+	}
+	return callers
+}
+
+// withCallers appends the callers of the calls recorded from the given position in the call history
+func withCallers(result *mismatch, entry *funcEntry, from int) *mismatch {
+	var lines = []string{}
+	for index := from; index < len(entry.history); index++ {
+		if len(entry.history[index].callers) > 0 {
+			lines = append(lines, fmt.Sprintf("call #%v: %v", index+1, strings.Join(entry.history[index].callers, " <- ")))
+		}
+	}
+	if len(lines) == 0 {
+		return result
+	}
+	return &mismatch{
+		format: result.format + "\nsurplus calls from:\n\t%v",
+		args:   append(result.args, strings.Join(lines, "\n\t")),
+	}
+}
+
+// sourceFile is the path of this source file, whose frames are skipped when locating the declaration of a setup
+var _, sourceFile, _, _ = runtime.Caller(0)
+
+func declaredAt() string {
+	var pcs = make([]uintptr, 32)
+	var frames = runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		var frame, more = frames.Next()
+		if frame.File != sourceFile {
+			return fmt.Sprint(filepath.Base(frame.File), ":", frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func (m *mocker) compareNormalParameters(name string, calls int, entry *funcEntry, mock *mockEntry, actuals []reflect.Value) {
+	m.tester.Helper()
+	var expects = mock.parameters
+	if len(expects) != len(actuals) {
+		m.errorf(
+			"[%v] Invalid number of parameters at call #%v: expect %v, actual %v",
+			name,
+			calls,
+			len(expects),
+			len(actuals),
+		)
+		return
+	}
+	var results = []*mismatch{}
+	for index, actual := range actuals {
+		if result := m.doComparison(entry, index+1, expects[index], actual); result != nil {
+			results = append(results, result)
+		}
+	}
+	m.reportMismatches(name, calls, entry, mock, actuals, len(actuals), results)
+}
+
+func (m *mocker) compareVariadicParameters(name string, calls int, entry *funcEntry, mock *mockEntry, actuals []reflect.Value) {
+	m.tester.Helper()
+	var expects = mock.parameters
+	var results = []*mismatch{}
+	for index, actual := range actuals {
+		if index != len(actuals)-1 {
+			if result := m.doComparison(entry, index+1, expects[index], actual); result != nil {
+				results = append(results, result)
+			}
+		} else {
+			if actual.Len() != len(expects)-index {
+				m.errorf(
+					"[%v] Invalid number of variadic parameters at call #%v: expect %v, actual %v",
+					name,
+					calls,
+					len(expects)-index,
+					actual.Len(),
+				)
+				return
+			}
+			for i := index; i < len(expects); i++ {
+				var expect = expects[i]
+				var item = actual.Index(i - index)
+				if result := m.doComparison(entry, index+1, expect, item); result != nil {
+					results = append(results, result)
+				}
+			}
+		}
+	}
+	m.reportMismatches(name, calls, entry, mock, actuals, len(expects), results)
+}
+
+func snapshotArguments(funcType reflect.Type, args []reflect.Value) []reflect.Value {
+	var snapshots = make([]reflect.Value, 0, len(args))
+	for index, arg := range args {
+		var snapshot = reflect.New(arg.Type()).Elem()
+		if funcType.IsVariadic() && index == len(args)-1 && !arg.IsNil() {
+			snapshot.Set(reflect.MakeSlice(arg.Type(), arg.Len(), arg.Len()))
+			reflect.Copy(snapshot, arg)
+		} else {
+			snapshot.Set(arg)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
+func flattenArguments(funcType reflect.Type, args []reflect.Value) []reflect.Value {
+	if !funcType.IsVariadic() || len(args) == 0 {
+		return args
+	}
+	var last = len(args) - 1
+	var flattened = append([]reflect.Value{}, args[:last]...)
+	for i := 0; i < args[last].Len(); i++ {
+		flattened = append(flattened, args[last].Index(i))
+	}
+	return flattened
+}
+
+func (m *mocker) diffParameters(entry *funcEntry, expects []interface{}, args []reflect.Value) []string {
+	var actuals = args
+	if !entry.asSlice {
+		actuals = flattenArguments(entry.funcType, args)
+	}
+	if len(expects) != len(actuals) {
+		return []string{fmt.Sprintf("expect %v parameters, actual %v", len(expects), len(actuals))}
+	}
+	var diffs = []string{}
+	for index, actual := range actuals {
+		var result = m.compareParameter(expects[index], index+1, actual)
+		if result != nil {
+			diffs = append(diffs, fmt.Sprintf("parameter #%v "+result.format, append([]interface{}{index + 1}, result.args...)...))
+		}
+	}
+	return diffs
+}
+
+func (m *mocker) matchParameters(entry *funcEntry, expects []interface{}, args []reflect.Value) bool {
+	var actuals = args
+	if !entry.asSlice {
+		actuals = flattenArguments(entry.funcType, args)
+	}
+	if len(expects) != len(actuals) {
+		return false
+	}
+	for index, actual := range actuals {
+		if m.compareParameter(expects[index], index+1, actual) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func interfaceArguments(funcType reflect.Type, args []reflect.Value) []interface{} {
+	var values = []interface{}{}
+	for _, arg := range flattenArguments(funcType, args) {
+		values = append(values, arg.Interface())
+	}
+	return values
+}
+
+func distinctMismatches(entry *funcEntry) []*mismatch {
+	var results = []*mismatch{}
+	var seen = [][]interface{}{}
+	for index, record := range entry.history {
+		var values = interfaceArguments(entry.funcType, record.args)
+		var former = slices.IndexFunc(seen, func(other []interface{}) bool {
+			return reflect.DeepEqual(other, values)
+		})
+		seen = append(seen, values)
+		if former >= 0 {
+			results = append(results, &mismatch{
+				format: "[%v] Duplicate arguments at call #%v: (%v) same as call #%v",
+				args: []interface{}{
+					entry.name,
+					index + 1,
+					entry.renderArguments(flattenArguments(entry.funcType, record.args)),
+					former + 1,
+				},
+			})
+		}
+	}
+	return results
+}
+
+func (m *mocker) anyOrderMismatch(entry *funcEntry) *mismatch {
+	var missing = append([][]interface{}{}, entry.anyOrders...)
+	var extra = [][]interface{}{}
+	for _, args := range entry.collected {
+		var found = false
+		for i, expects := range missing {
+			if m.matchParameters(entry, expects, args) {
+				missing = append(missing[:i], missing[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			extra = append(extra, interfaceArguments(entry.funcType, args))
+		}
+	}
+	if len(missing) == 0 && len(extra) == 0 {
+		return nil
+	}
+	return &mismatch{
+		format: "[%v] Parameter mismatch in any order: missing %v, extra %v",
+		args:   []interface{}{entry.name, missing, extra},
+	}
+}
+
+func (m *mocker) returnZeros(funcType reflect.Type) []reflect.Value {
+	var count = funcType.NumOut()
+	var rets = make([]reflect.Value, 0, count)
+	for i := 0; i < count; i++ {
+		rets = append(rets, reflect.Zero(funcType.Out(i)))
+	}
+	return rets
+}
+
+func (m *mocker) constructReturns(name string, calls int, funcType reflect.Type, returns []interface{}) []reflect.Value {
+	m.tester.Helper()
+	var count = funcType.NumOut()
+	if count != len(returns) {
+		m.errorf(
+			"[%v] Invalid number of returns at call #%v: expect %v, actual %v",
+			name,
+			calls,
+			count,
+			len(returns),
+		)
+		return m.returnZeros(funcType)
+	}
+	var rets = []reflect.Value{}
+	for i, ret := range returns {
+		var value = reflect.ValueOf(ret)
+		if !value.IsValid() {
+			rets = append(rets, reflect.Zero(funcType.Out(i)))
+		} else if !value.Type().AssignableTo(funcType.Out(i)) {
+			m.errorf(
+				"[%v] Invalid type of return #%v at call #%v: expect %v, actual %v",
+				name,
+				i+1,
+				calls,
+				funcType.Out(i),
+				value.Type(),
+			)
+			rets = append(rets, reflect.Zero(funcType.Out(i)))
+		} else {
+			rets = append(rets, value)
+		}
+	}
+	return rets
+}
+
+func isNilValue(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+	switch value.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice, reflect.UnsafePointer:
+		return value.IsNil()
+	}
+	return false
+}
+
+func (e *mockEntry) nextReturns(used int) []interface{} {
+	var returns = e.returns
+	if e.cyclic {
+		returns = e.sequence[(used-1)%len(e.sequence)]
+	} else if e.sequence != nil {
+		returns = e.sequence[min(used, len(e.sequence))-1]
+	}
+	if !e.copying {
+		return returns
+	}
+	var copies = make([]interface{}, 0, len(returns))
+	for _, value := range returns {
+		if value == nil {
+			copies = append(copies, nil)
+			continue
+		}
+		copies = append(copies, deepCopy(reflect.ValueOf(value), map[uintptr]reflect.Value{}).Interface())
+	}
+	return copies
+}
+
+func cloneByMethod(value reflect.Value) (reflect.Value, bool) {
+	for _, name := range []string{"Clone", "Copy"} {
+		var method = value.MethodByName(name)
+		if !method.IsValid() {
+			continue
+		}
+		var methodType = method.Type()
+		if methodType.NumIn() == 0 && methodType.NumOut() == 1 && methodType.Out(0) == value.Type() {
+			return method.Call(nil)[0], true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func deepCopy(value reflect.Value, visited map[uintptr]reflect.Value) reflect.Value {
+	if isNilValue(value) {
+		return value
+	}
+	var cloned, ok = cloneByMethod(value)
+	if ok {
+		return cloned
+	}
+	switch value.Kind() {
+	case reflect.Pointer:
+		var copied, found = visited[value.Pointer()]
+		if found {
+			return copied
+		}
+		copied = reflect.New(value.Type().Elem())
+		visited[value.Pointer()] = copied
+		copied.Elem().Set(deepCopy(value.Elem(), visited))
+		return copied
+	case reflect.Interface:
+		var copied = reflect.New(value.Type()).Elem()
+		copied.Set(deepCopy(value.Elem(), visited))
+		return copied
+	case reflect.Slice:
+		var copied = reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(deepCopy(value.Index(i), visited))
+		}
+		return copied
+	case reflect.Array:
+		var copied = reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(deepCopy(value.Index(i), visited))
+		}
+		return copied
+	case reflect.Map:
+		var copied = reflect.MakeMapWithSize(value.Type(), value.Len())
+		var iter = value.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), deepCopy(iter.Value(), visited))
+		}
+		return copied
+	case reflect.Struct:
+		var copied = reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			if copied.Field(i).CanSet() {
+				copied.Field(i).Set(deepCopy(value.Field(i), visited))
+			}
+		}
+		return copied
+	}
+	return value
+}
+
+func (m *mocker) dispatch(name string, calls int, entry *funcEntry, args []reflect.Value) *mockEntry {
+	m.tester.Helper()
+	var remaining = [][]interface{}{}
+	for index, mock := range entry.mocks {
+		if index > 0 && entry.mocks[index-1] == mock {
+			continue
+		}
+		if mock.used >= mock.times {
+			continue
+		}
+		if m.matchParameters(entry, mock.parameters, args) {
+			return mock
+		}
+		remaining = append(remaining, mock.parameters)
+	}
+	var last = entry.mocks[len(entry.mocks)-1]
+	if entry.unbounded && m.matchParameters(entry, last.parameters, args) {
+		return last
+	}
+	m.errorf(
+		"[%v] Parameter mismatch at call #%v: actual %v, matching none of the remaining setups %v",
+		name,
+		calls,
+		interfaceArguments(entry.funcType, args),
+		remaining,
+	)
+	return nil
+}
+
+// reportOrder reports a call out of order, keeping it for ExpectationsWereMet as well
+//
+//	the call is not reported to the tester with WithoutCleanupVerification option, just like the calls beyond the expected number
+func (m *mocker) reportOrder(format string, args ...interface{}) {
+	m.tester.Helper()
+	m.disorders = append(m.disorders, &mismatch{format: format, args: args})
+	if !m.manual {
+		m.errorf(format, args...)
+	}
+}
+
+func (m *mocker) verifyStrictOrder(name string, calls int, mock *mockEntry) {
+	m.tester.Helper()
+	if m.next < 0 || mock.used > mock.times {
+		return
+	}
+	if m.declared[m.next].mock == mock {
+		m.next++
+		return
+	}
+	m.reportOrder(
+		"[%v] Unexpected call order at call #%v: expect next call to [%v] declared as setup #%v, actual setup #%v",
+		name,
+		calls,
+		m.declared[m.next].entry.name,
+		m.declared[m.next].mock.position,
+		mock.position,
+	)
+	m.next = -1
+}
+
+func (m *mocker) verifyOrder(name string, calls int, mock *mockEntry) {
+	m.tester.Helper()
+	for _, after := range mock.afters {
+		if after.mock.used < after.mock.times {
+			m.reportOrder(
+				"[%v] Unexpected call order at call #%v: expect setup #%v [%v] to be called before setup #%v",
+				name,
+				calls,
+				after.mock.position,
+				after.entry.name,
+				mock.position,
+			)
+		}
+	}
+	for _, required := range mock.requires {
+		if len(required.history) == 0 {
+			m.reportOrder(
+				"[%v] Unexpected call order at call #%v: expect [%v] to be called at least once before",
+				name,
+				calls,
+				required.name,
+			)
+		}
+	}
+	for _, order := range mock.orderings {
+		order.observed = append(order.observed, name)
+		for index, previous := range order.mocks {
+			if previous == mock {
+				break
+			}
+			if previous.used >= previous.times {
+				continue
+			}
+			if order.name != "" {
+				m.reportOrder(
+					"[%v] Unexpected call order in sequence [%v] at call #%v: expect position #%v [%v] to be called before, actual position #%v",
+					name,
+					order.name,
+					calls,
+					index+1,
+					order.names[index],
+					slices.Index(order.mocks, mock)+1,
+				)
+			} else {
+				m.reportOrder(
+					"[%v] Unexpected call order at call #%v: expect [%v] to be called before, actual order %v",
+					name,
+					calls,
+					order.names[index],
+					order.observed,
+				)
+			}
+			break
+		}
+	}
+}
+
+func (m *mocker) receiveReturns(name string, calls int, entry *funcEntry, channel <-chan []interface{}) ([]interface{}, bool) {
+	m.tester.Helper()
+	entry.blocked.Add(1)
+	defer entry.blocked.Add(-1)
+	select {
+	case returns, ok := <-channel:
+		if !ok {
+			m.errorf(
+				"[%v] Returns channel closed before returns were sent at call #%v",
+				name,
+				calls,
+			)
+		}
+		return returns, ok
+	case <-m.done:
+		return nil, false
+	}
+}
+
+// reportLateCall logs the first call arriving after the mocker is verified and reset, e.g. from a leaked goroutine
+//
+//	the tester panics when logging after the test is finished, in which case the late call is dropped quietly
+func (m *mocker) reportLateCall(name string) {
+	m.tester.Helper()
+	if m.late {
+		return
+	}
+	m.late = true
+	defer func() {
+		_ = recover()
+	}()
+	m.tester.Logf("[%v] Mocked function or method called after test completion, possibly from a leaked goroutine", name)
+}
+
+func (m *mocker) makeFunc(name string, funcPtr uintptr, funcType reflect.Type) reflect.Value {
+	m.tester.Helper()
+	return reflect.MakeFunc(
+		funcType,
+		func(args []reflect.Value) (results []reflect.Value) {
+			m.tester.Helper()
+			var record = &invocation{args: snapshotArguments(funcType, args), callers: m.callerInfo()}
+			var rendered = renderArguments(args)
+			m.locker.Lock()
+			var locked = true
+			var unlock = func() {
+				if locked {
+					locked = false
+					m.locker.Unlock()
+				}
+			}
+			defer unlock()
+			if m.closed {
+				m.reportLateCall(name)
+				return m.returnZeros(funcType)
+			}
+			var entry, found = m.entries[funcPtr]
+			if !found && m.parent != nil {
+				unlock()
+				return m.parent.forward(name, funcPtr, funcType, args)
+			}
+			if !found && m.auto {
+				m.funcs++
+				entry = &funcEntry{
+					name:     name,
+					funcType: funcType,
+					stub:     true,
+					auto:     true,
+					mocks:    make([]*mockEntry, 0),
+					order:    m.funcs,
+				}
+				m.entries[funcPtr] = entry
+			} else if !found {
+				m.fatalf(
+					"The underlying function or method %v was never setup",
+					name,
+				)
+				return nil
+			}
+			if len(args) > 0 && args[0].Kind() == reflect.Pointer {
+				var instance, found = entry.instances[args[0].Pointer()]
+				if found {
+					entry = instance
+				} else if len(entry.mocks) == 0 && m.parent != nil {
+					unlock()
+					return m.parent.forward(name, funcPtr, funcType, args)
+				}
+			}
+			var name = entry.name
+			defer m.recover(name)
+			entry.actual++
+			entry.history = append(entry.history, record)
+			if m.called != nil {
+				close(m.called)
+				m.called = nil
+			}
+			var sequence = entry.actual
+			var selected *mockEntry
+			var cleanups []*atomic.Int64
+			defer func() {
+				if !locked {
+					m.locker.Lock()
+					defer m.locker.Unlock()
+				}
+				record.returns = results
+				record.cleanups = cleanups
+			}()
+			defer func() {
+				m.logCall(name, sequence, args, selected, results)
+			}()
+			m.record(name, entry.actual, rendered)
+			if entry.auto {
+				var first = entry.actual == 1
+				unlock()
+				if first {
+					m.tester.Logf("[%v] Auto-stubbed without setup, returning zero values for this and further calls", name)
+				}
+				return m.returnZeros(funcType)
+			}
+			var index = entry.actual
+			if entry.unbounded {
+				index = min(index, len(entry.mocks))
+			} else if entry.actual > entry.expect || entry.actual > len(entry.mocks) {
+				if !entry.stub || len(entry.mocks) == 0 {
+					if !m.manual {
+						var pending = entry.pending()
+						var result = withDeclared(&mismatch{
+							format: "[%v] Unepxected number of calls: expect %v, actual %v with arguments (%v)",
+							args:   []interface{}{pending.display(name), entry.expect, entry.actual, entry.renderArguments(flattenArguments(funcType, args))},
+						}, pending.declared)
+						result = withCallers(result, entry, len(entry.history)-1)
+						result = m.withPending(result)
+						m.errorf(result.format, result.args...)
+					}
+					entry.verified = true
+					if m.overPanic {
+						panic(overCall(fmt.Sprintf(
+							"[%v] Unepxected number of calls: expect %v, actual %v",
+							name,
+							entry.expect,
+							entry.actual,
+						)))
+					}
+					return m.returnZeros(funcType)
+				}
+				entry.actual = len(entry.mocks)
+				index = entry.actual
+			}
+			var calls = entry.actual
+			var mock = entry.mocks[index-1]
+			if entry.unordered && !entry.stub {
+				mock = m.dispatch(name, calls, entry, args)
+				if mock == nil {
+					return m.returnZeros(funcType)
+				}
+			}
+			mock.used++
+			var used = mock.used
+			selected = mock
+			name = mock.display(name)
+			if m.strict && !entry.stub {
+				m.verifyStrictOrder(name, calls, mock)
+			}
+			m.verifyOrder(name, calls, mock)
+			if mock.anyOrder {
+				entry.collected = append(entry.collected, snapshotArguments(funcType, args))
+			}
+			unlock()
+			if mock.notify != nil {
+				defer func() {
+					mock.notify <- struct{}{}
+				}()
+			}
+			if !mock.anyOrder && !mock.distinct && !entry.stub && !entry.unordered {
+				if funcType.IsVariadic() && !entry.asSlice {
+					m.compareVariadicParameters(name, calls, entry, mock, args)
+				} else {
+					m.compareNormalParameters(name, calls, entry, mock, args)
+				}
+			}
+			if mock.callback != nil {
+				var params = []interface{}{}
+				for _, arg := range args {
+					params = append(params, arg.Interface())
+				}
+				mock.callback(calls, params...)
+			}
+			var overrides, overridden = mock.conditionalReturns(args)
+			if overridden {
+				results = m.constructReturns(name, calls, funcType, overrides)
+			} else if mock.zero {
+				return m.returnZeros(funcType)
+			} else if mock.channel != nil {
+				var returns, ok = m.receiveReturns(name, calls, entry, mock.channel)
+				if !ok {
+					return m.returnZeros(funcType)
+				}
+				results = m.constructReturns(name, calls, funcType, returns)
+			} else {
+				results = m.constructReturns(name, calls, funcType, mock.nextReturns(used))
+			}
+			if mock.tracking {
+				results, cleanups = trackCleanups(results)
+			}
+			if mock.postback != nil {
+				var returns = []interface{}{}
+				for _, result := range results {
+					returns = append(returns, result.Interface())
+				}
+				mock.postback(calls, returns...)
+			}
+			return results
+		},
+	)
+}
+
+func (m *mocker) setup(name string, stub bool, funcPtr uintptr, funcType reflect.Type) {
+	m.tester.Helper()
+	m.setupEntry(name, stub, m.entries, funcPtr, funcType)
+}
+
+func (m *mocker) setupEntry(name string, stub bool, entries map[uintptr]*funcEntry, key uintptr, funcType reflect.Type) {
+	m.tester.Helper()
+	if m.current != nil || m.temp != nil {
+		m.fatalf(
+			"A former setup for function or method [%v] was incomplete."+
+				" Did you miss calling the Once/Twice/Times method in the end?",
+			name,
+		)
+		return
+	}
+	var entry, found = entries[key]
+	if found && !entry.verified {
+		if entry.auto {
+			entry.auto = false
+			entry.actual = 0
+		}
+		if entry.never {
+			entry.never = false
+			entry.mocks = make([]*mockEntry, 0)
+		}
+		if len(entry.mocks) == 0 && !entry.nocall {
+			entry.stub = stub
+		}
+		if entry.stub != stub {
+			if entry.stub {
+				m.fatalf(
+					"A former setup for function or method [%v] was a Stub but current setup is a Mock."+
+						" We do not support mixing Stub and Mock for the same function or method at the moment.",
+					name,
+				)
+			} else {
+				m.fatalf(
+					"A former setup for function or method [%v] was a Mock but current setup is a Stub."+
+						" We do not support mixing Stub and Mock for the same function or method at the moment.",
+					name,
+				)
+			}
+			return
+		}
+		if entry.nocall {
+			m.fatalf("A former setup for function or method [%v] was to be not called,"+
+				" therefore no more Mock or Stub can be setup for it now.",
+				name,
+			)
+			return
+		}
+		if entry.unbounded {
+			m.fatalf("A former setup for function or method [%v] was for any number of calls,"+
+				" therefore no more Mock or Stub can be setup for it now.",
+				name,
+			)
+			return
+		}
+		m.current = entry
+		m.temp = &mockEntry{copying: m.copying}
+		return
+	}
+	m.funcs++
+	var retired = entry
+	entry = &funcEntry{
+		name:     name,
+		funcType: funcType,
+		stub:     stub,
+		actual:   0,
+		mocks:    make([]*mockEntry, 0),
+		order:    m.funcs,
+	}
+	if found {
+		// a verified entry only retires its setups, while its calls are kept for CallsOf, CallCount and alike
+		entry.history = retired.history
+		entry.instances = retired.instances
+	}
+	entries[key] = entry
+	m.current = entry
+	m.temp = &mockEntry{copying: m.copying}
+}
+
+// Mock allows one to mock either a function or a struct method visible to the current package
+//
+//	expectFunc pass in the pointer to the function to be mocked
+//	returns an Expecter instance to allow setting up parameter expectations
+func (m *mocker) Mock(expectFunc interface{}) Expecter {
+	m.tester.Helper()
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	var funcPtr, name = m.getFuncPointer(expectFunc)
+	if !m.verifyInstantiation(name, expectFunc) {
+		return m
+	}
+	if !m.verifyTarget(name) {
+		return m
+	}
+	var funcType = reflect.TypeOf(expectFunc)
+	m.setup(name, false, funcPtr, funcType)
+	m.applyPatch(
+		name,
+		reflect.ValueOf(expectFunc),
+		m.makeFunc(name, funcPtr, funcType),
+	)
+	return m
+}
+
+// Stub allows one to stub either a function or a struct method visible to the current package
+//
+//	expectFunc pass in the pointer to the function to be mocked
+//	returns a Returner instance to allow setting up return expectations
+func (m *mocker) Stub(expectFunc interface{}) Returner {
+	m.tester.Helper()
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	var funcPtr, name = m.getFuncPointer(expectFunc)
+	if !m.verifyInstantiation(name, expectFunc) {
+		return &returner{m}
+	}
+	if !m.verifyTarget(name) {
+		return &returner{m}
+	}
+	var funcType = reflect.TypeOf(expectFunc)
+	m.setup(name, true, funcPtr, funcType)
+	m.applyPatch(
+		name,
+		reflect.ValueOf(expectFunc),
+		m.makeFunc(name, funcPtr, funcType),
+	)
+	return &returner{m}
+}
+
+// MockMethod allows one to mock a struct method by its name, e.g. an unexported method of a type from another package
+//
+//	the receiver is passed in as the first parameter of the calls, as if mocking the method expression,
+//	while the signature of an unexported method must be given, as it is not known to reflect
+//
+//	target pass in a value of the receiver type, e.g. (*foo)(nil) for the methods with pointer receiver
+//	methodName pass in the name of the struct method to be mocked
+//	signature pass in a function value of the method expression's type for an unexported method, e.g. (func(*foo, string) error)(nil)
+//	returns an Expecter instance to allow setting up parameter expectations
+func (m *mocker) MockMethod(target interface{}, methodName string, signature ...interface{}) Expecter {
+	m.tester.Helper()
+	m.setupMethod(false, target, methodName, signature)
+	return m
+}
+
+// StubMethod allows one to stub a struct method by its name, e.g. an unexported method of a type from another package
+//
+//	target pass in a value of the receiver type, e.g. (*foo)(nil) for the methods with pointer receiver
+//	methodName pass in the name of the struct method to be stubbed
+//	signature pass in a function value of the method expression's type for an unexported method, e.g. (func(*foo, string) error)(nil)
+//	returns a Returner instance to allow setting up return expectations
+func (m *mocker) StubMethod(target interface{}, methodName string, signature ...interface{}) Returner {
+	m.tester.Helper()
+	m.setupMethod(true, target, methodName, signature)
+	return &returner{m}
+}
+
+// setupMethod sets up the method resolved by name, patching an unexported one through ApplyPrivateMethod of gomonkey
+func (m *mocker) setupMethod(stub bool, target interface{}, methodName string, signature []interface{}) {
+	m.tester.Helper()
+	var method, found = m.getMethod(target, methodName, signature)
+	if !found {
+		return
+	}
+	if token.IsExported(methodName) {
+		if stub {
+			m.Stub(method.Interface())
+		} else {
+			m.Mock(method.Interface())
+		}
+		return
+	}
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	var funcPtr, name = m.getFuncPointer(method.Interface())
+	var funcType = method.Type()
+	m.setup(name, stub, funcPtr, funcType)
+	m.applyPrivatePatch(
+		name,
+		method,
+		reflect.TypeOf(target),
+		methodName,
+		m.makeFunc(name, funcPtr, funcType),
+	)
+}
+
+// MockOn allows one to mock a struct method only for the calls on the given instance
+//
+//	the calls on other instances are served by the setups from Mock or Stub method as usual
+//
+//	instance pass in the pointer to the struct instance, i.e. the receiver of the calls to be mocked
+//	expectFunc pass in the struct method to be mocked, e.g. (*foo).bar
+//	returns an Expecter instance to allow setting up parameter expectations
+func (m *mocker) MockOn(instance interface{}, expectFunc interface{}) Expecter {
+	m.tester.Helper()
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	var funcPtr, name = m.getFuncPointer(expectFunc)
+	if !m.verifyInstantiation(name, expectFunc) || !m.verifyTarget(name) {
+		return m
+	}
+	var funcType = reflect.TypeOf(expectFunc)
+	var receiver = reflect.ValueOf(instance)
+	if receiver.Kind() != reflect.Pointer || funcType.NumIn() == 0 || funcType.In(0) != receiver.Type() {
+		m.fatalf(
+			"Unexpected instance [%v] passed to MockOn, only a pointer receiver of method [%v] is supported",
+			instance,
+			name,
+		)
+		return m
+	}
+	var entry, found = m.entries[funcPtr]
+	if !found {
+		m.funcs++
+		entry = &funcEntry{
+			name:     name,
+			funcType: funcType,
+			mocks:    make([]*mockEntry, 0),
+			order:    m.funcs,
+		}
+		m.entries[funcPtr] = entry
+		m.applyPatch(
+			name,
+			reflect.ValueOf(expectFunc),
+			m.makeFunc(name, funcPtr, funcType),
+		)
+	}
+	if entry.instances == nil {
+		entry.instances = make(map[uintptr]*funcEntry)
+	}
+	m.setupEntry(
+		fmt.Sprintf("%v on %p", name, instance),
+		false,
+		entry.instances,
+		receiver.Pointer(),
+		funcType,
+	)
+	return m
+}
+
+// MockVar allows one to patch a package-level variable, e.g. `var maxRetries = 3`, with the given value during the test
+//
+//	the variable is restored to its original value at the end of test, while patching it again simply overrides the value,
+//	and without a value the function variable is mocked instead, exactly as MockFuncVar does
+//
+//	target pass in the pointer to the variable to be patched
+//	value pass in the value assignable to the variable for the rest of the test, or nothing to mock a function variable
+//	returns an Expecter instance to allow setting up parameter expectations when mocking a function variable
+func (m *mocker) MockVar(target interface{}, values ...interface{}) Expecter {
+	m.tester.Helper()
+	if len(values) == 0 {
+		return m.MockFuncVar(target)
+	}
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	if len(values) > 1 {
+		m.fatalf(
+			"Unexpected %v values passed to MockVar, only a single value is supported",
+			len(values),
+		)
+		return m
+	}
+	var value = values[0]
+	var pointer = reflect.ValueOf(target)
+	if pointer.Kind() != reflect.Pointer || pointer.IsNil() {
+		m.fatalf(
+			"Unexpected target [%v] passed to MockVar, only a pointer to a variable is supported",
+			target,
+		)
+		return m
+	}
+	var variable = pointer.Elem()
+	var replacement = reflect.ValueOf(value)
+	if value == nil {
+		switch variable.Kind() {
+		case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice, reflect.UnsafePointer:
+			replacement = reflect.Zero(variable.Type())
+		}
+	}
+	if !replacement.IsValid() || !replacement.Type().AssignableTo(variable.Type()) {
+		m.fatalf(
+			"Unexpected value [%v] passed to MockVar, which is not assignable to the variable of type %v",
+			value,
+			variable.Type(),
+		)
+		return m
+	}
+	if !m.claimPatch(fmt.Sprint("variable of ", variable.Type()), pointer.Pointer()) {
+		return m
+	}
+	m.patchVariable(variable, replacement)
+	return m
+}
+
+// MockFuncVar allows one to mock a function variable, e.g. `var now = time.Now`, visible to the current package
+//
+//	only the calls through the variable are affected, and the variable is restored at the end of test
+//
+//	target pass in the pointer to the function variable to be mocked
+//	returns an Expecter instance to allow setting up parameter expectations
+func (m *mocker) MockFuncVar(target interface{}) Expecter {
+	m.tester.Helper()
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	var value = reflect.ValueOf(target)
+	if value.Kind() != reflect.Pointer || value.Elem().Kind() != reflect.Func {
+		m.fatalf(
+			"Unexpected target [%v] passed to MockFuncVar, only a pointer to a function variable is supported",
+			target,
+		)
+		return m
+	}
+	var funcPtr = value.Pointer()
+	var funcType = value.Elem().Type()
+	var name = fmt.Sprint("variable of ", funcType)
+	if !value.Elem().IsNil() {
+		var _, funcName = m.getFuncPointer(value.Elem().Interface())
+		name = fmt.Sprint("variable of ", funcName)
+	}
+	var _, found = m.entries[funcPtr]
+	m.setup(name, false, funcPtr, funcType)
+	if !found {
+		m.applyVarPatch(name, target, m.makeFunc(name, funcPtr, funcType))
+	}
+	return m
+}
+
+// Expects allows one to setup a list of parameters to be verified during a function or a struct method call
+//
+//	parameters pass in the list of parameters to be verified,
+//	  just like how they are normally passed into the original function or struct method
+//	returns a Returner instance to allow setting up return expectations
+func (m *mocker) Expects(parameters ...any) Returner {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to Expects without setting up an anticipated function or method",
+		)
+		return &returner{m}
+	}
+	if m.current.stub && len(parameters) > 0 {
+		m.fatalf(
+			"function or method [%v] is setup as a Stub, which does not verify parameters."+
+				" Try using Mock method instead to setup parameter expectations.",
+			m.current.name,
+		)
+		return &returner{m}
+	}
+	m.temp.parameters = parameters
+	return &returner{m}
+}
+
+// Named allows one to override the name of the underlying function or struct method shown in failure messages
+//
+//	name pass in the customized display name, e.g. "Foo"
+//	returns the same Expecter instance to allow setting up parameter expectations
+func (m *mocker) Named(name string) Expecter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to Named without setting up an anticipated function or method",
+		)
+		return m
+	}
+	m.current.name = name
+	return m
+}
+
+// ExpectsAnyOrder allows one to setup lists of parameters to be verified in any order across calls
+//
+//	the calls are collected and verified as an unordered multiset against the lists at the end of test
+//	typically one would complete the setup with Times(len(valueSets)) to expect one call per list
+//
+//	valueSets pass in the lists of parameters to be verified, each list for one call,
+//	  just like how they are normally passed into the original function or struct method
+//	returns a Returner instance to allow setting up return expectations
+func (m *mocker) ExpectsAnyOrder(valueSets ...[]any) Returner {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to ExpectsAnyOrder without setting up an anticipated function or method",
+		)
+		return &returner{m}
+	}
+	m.current.anyOrders = append(m.current.anyOrders, valueSets...)
+	m.temp.anyOrder = true
+	return &returner{m}
+}
+
+// ExpectsDistinct allows one to verify that no two calls to the underlying function or struct method share the same arguments
+//
+//	the parameters of each call are not verified otherwise, while duplicates are reported at the end of test
+//	this applies to all calls of the underlying function or struct method, e.g. for idempotency tests
+//
+//	returns a Returner instance to allow setting up return expectations
+func (m *mocker) ExpectsDistinct() Returner {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to ExpectsDistinct without setting up an anticipated function or method",
+		)
+		return &returner{m}
+	}
+	m.current.distinct = true
+	m.temp.distinct = true
+	return &returner{m}
+}
+
+// VariadicAsSlice allows one to verify the variadic parameters of the underlying function or struct method
+//
+//	as a single slice parameter, instead of expanding them and verifying one by one
+//	this applies to all setups of the underlying function or struct method
+//
+//	returns the same Expecter instance to allow setting up parameter expectations
+func (m *mocker) VariadicAsSlice() Expecter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to VariadicAsSlice without setting up an anticipated function or method",
+		)
+		return m
+	}
+	m.current.asSlice = true
+	return m
+}
+
+// Formatted allows one to customize how the values are rendered in failure messages
+//
+//	the formatter is used for the values in parameter mismatches and the arguments of unexpected calls,
+//	  e.g. to redact secrets or truncate big blobs, and this applies to all setups of the underlying function or struct method
+//
+//	formatter pass in the function rendering each value into the text shown in failure messages
+//	returns the same Expecter instance to allow setting up parameter expectations
+func (m *mocker) Formatted(formatter func(value any) string) Expecter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to Formatted without setting up an anticipated function or method",
+		)
+		return m
+	}
+	m.current.formatter = formatter
+	return m
+}
+
+// Unordered allows one to dispatch each call to the underlying function or struct method
+//
+//	to whichever remaining setup matching its parameters, instead of consuming setups in order
+//	this applies to all setups of the underlying function or struct method
+//
+//	returns the same Expecter instance to allow setting up parameter expectations
+func (m *mocker) Unordered() Expecter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to Unordered without setting up an anticipated function or method",
+		)
+		return m
+	}
+	m.current.unordered = true
+	return m
+}
+
+// NotCalled verifies that no call is expected to the underlying function or struct method
+//
+//	the underlying function or struct method cannot be mocked or stubbed again in the same test
+//	this completes the current Mock sequence, as well as overrides any previous mock or stub
+func (m *mocker) NotCalled() {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to NotCalled without setting up an anticipated function or method",
+		)
+		return
+	}
+	m.current.nocall = true
+	m.current.expect = 0
+	m.current.mocks = []*mockEntry{{}}
+	m.temp = nil
+	m.current = nil
+}
+
+// Never verifies that no call is expected to the underlying function or struct method, unless setup again later
+//
+//	unlike NotCalled, the underlying function or struct method can be mocked or stubbed again in the same test,
+//	  in which case the new setup replaces the expectation of no call from then on
+//	this completes the current Mock sequence, as well as overrides any previous mock or stub
+func (m *mocker) Never() {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to Never without setting up an anticipated function or method",
+		)
+		return
+	}
+	m.current.never = true
+	m.current.expect = 0
+	m.current.mocks = []*mockEntry{{}}
+	m.temp = nil
+	m.current = nil
+}
+
+// Returns allows one to setup a list of values to be returned after a function or a struct method call
+//
+//	values pass in the list of values to be returned,
+//	  just like how they are normally returned from the original function or struct method
+//	returns a Counter instance to allow setting up execution expectations
+func (m *mocker) Returns(values ...any) Counter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to Returns without setting up an anticipated function or method",
+		)
+		return &counter{m}
+	}
+	m.temp.returns = values
+	return &counter{m}
+}
+
+// ReturnsError allows one to setup an error to be returned after a function or a struct method call
+//
+//	all other returns are setup with zero values, and the last return must be of type error
+//
+//	err pass in the error to be returned at the last return position
+//	returns a Counter instance to allow setting up execution expectations
+func (m *mocker) ReturnsError(err error) Counter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to ReturnsError without setting up an anticipated function or method",
+		)
+		return &counter{m}
+	}
+	var count = m.current.funcType.NumOut()
+	if count == 0 || m.current.funcType.Out(count-1) != reflect.TypeFor[error]() {
+		m.fatalf(
+			"function or method [%v] cannot be setup with ReturnsError as its last return is not of type error",
+			m.current.name,
+		)
+		return &counter{m}
+	}
+	var values = make([]interface{}, count)
+	values[count-1] = err
+	m.temp.returns = values
+	return &counter{m}
+}
+
+// ReturnsZero allows one to setup zero values to be returned after a function or a struct method call
+//
+//	this documents the intent more clearly than enumerating the zero values in Returns
+//
+//	returns a Counter instance to allow setting up execution expectations
+func (m *mocker) ReturnsZero() Counter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to ReturnsZero without setting up an anticipated function or method",
+		)
+		return &counter{m}
+	}
+	m.temp.zero = true
+	return &counter{m}
+}
+
+// ReturnsFromChannel allows one to feed the values to be returned from a channel during each call
+//
+//	each call blocks until the list of values to be returned for that call is received from the channel
+//
+//	channel pass in the channel to send the list of values to be returned for each call,
+//	  just like how they are normally returned from the original function or struct method
+//	returns a Counter instance to allow setting up execution expectations
+func (m *mocker) ReturnsFromChannel(channel <-chan []any) Counter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to ReturnsFromChannel without setting up an anticipated function or method",
+		)
+		return &counter{m}
+	}
+	m.temp.channel = channel
+	return &counter{m}
+}
+
+// ReturnsCopy allows one to setup a list of values to be deep copied and returned after each call
+//
+//	this prevents the mutations on the returned values by one call from affecting subsequent calls
+//	values implementing a Clone or Copy method returning their own type are copied by that method,
+//	  otherwise unexported struct fields are copied shallowly as a best effort
+//
+//	values pass in the list of values to be deep copied and returned,
+//	  just like how they are normally returned from the original function or struct method
+//	returns a Counter instance to allow setting up execution expectations
+func (m *mocker) ReturnsCopy(values ...any) Counter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to ReturnsCopy without setting up an anticipated function or method",
+		)
+		return &counter{m}
+	}
+	m.temp.returns = values
+	m.temp.copying = true
+	return &counter{m}
+}
+
+// ReturnsChannel allows one to setup a newly created buffered channel to be returned after each call
+//
+//	the channel is returned at the first return position accepting it, while all other returns are zero values
+//	and it is also returned to the test, so that the test can push values to it
+//
+//	returner pass in the Returner instance from a mock or stub, or a typed Returner wrapping it, e.g. TypedReturner1
+//	size pass in the buffer size of the channel to be created
+//	returns the created channel as well as a Counter instance to allow setting up execution expectations
+func ReturnsChannel[T any](returner any, size int) (chan T, Counter) {
+	var m, ok = unwrapMocker(returner)
+	if !ok {
+		panic(fmt.Sprintf(
+			"Unexpected returner [%T] passed to ReturnsChannel, only the Returner from a mock or stub of a mocker is supported",
+			returner,
+		))
+	}
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to ReturnsChannel without setting up an anticipated function or method",
+		)
+		return nil, &counter{m}
+	}
+	var channel = make(chan T, size)
+	var channelType = reflect.TypeOf(channel)
+	var values = make([]interface{}, m.current.funcType.NumOut())
+	for i := range values {
+		var outType = m.current.funcType.Out(i)
+		if outType.Kind() == reflect.Chan && channelType.AssignableTo(outType) {
+			values[i] = channel
+			m.temp.returns = values
+			return channel, &counter{m}
+		}
+	}
+	m.fatalf(
+		"function or method [%v] cannot be setup with ReturnsChannel as none of its returns accepts [%v]",
+		m.current.name,
+		channelType,
+	)
+	return nil, &counter{m}
+}
+
+// unwrapMocker reaches the mocker behind a Returner, either directly or through the Returner embedded by a typed wrapper
+func unwrapMocker(stage any) (*mocker, bool) {
+	switch unwrapped := stage.(type) {
+	case *mocker:
+		return unwrapped, true
+	case *returner:
+		return unwrapped.mocker, true
+	}
+	var value = reflect.ValueOf(stage)
+	if value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil, false
+	}
+	var embedded = value.FieldByName("Returner")
+	if !embedded.IsValid() || embedded.Kind() != reflect.Interface || embedded.IsNil() {
+		return nil, false
+	}
+	return unwrapMocker(embedded.Interface())
+}
+
+// ReturnsSequence allows one to setup groups of values to be returned in order by consecutive calls
+//
+//	the final group is repeated for all remaining calls, which fits well with AnyTimes or AtLeast
+//
+//	groups pass in the groups of values to be returned, each group for one call,
+//	  just like how they are normally returned from the original function or struct method
+//	returns a Counter instance to allow setting up execution expectations
+func (m *mocker) ReturnsSequence(groups ...[]any) Counter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to ReturnsSequence without setting up an anticipated function or method",
+		)
+		return &counter{m}
+	}
+	if len(groups) == 0 {
+		m.fatalf(
+			"function or method [%v] cannot be setup with an empty return sequence",
+			m.current.name,
+		)
+		return &counter{m}
+	}
+	m.temp.sequence = groups
+	m.temp.cyclic = false
+	return &counter{m}
+}
+
+// ReturnsCycle allows one to setup groups of values to be returned in a repeating cycle by consecutive calls
+//
+//	after the final group is returned, the cycle starts over from the first group, which fits well with AnyTimes
+//
+//	groups pass in the groups of values to be returned, each group for one call,
+//	  just like how they are normally returned from the original function or struct method
+//	returns a Counter instance to allow setting up execution expectations
+func (m *mocker) ReturnsCycle(groups ...[]any) Counter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to ReturnsCycle without setting up an anticipated function or method",
+		)
+		return &counter{m}
+	}
+	if len(groups) == 0 {
+		m.fatalf(
+			"function or method [%v] cannot be setup with an empty return sequence",
+			m.current.name,
+		)
+		return &counter{m}
+	}
+	m.temp.sequence = groups
+	m.temp.cyclic = true
+	return &counter{m}
+}
+
+// SideEffect allows one to setup a callback function that is called during expectation verification
+//
+//	note that there is only one side effect for each mock or stub, and the newest overrides previous ones
+//
+//	callback pass in the customized callback function with an integer parameter `index`
+//	  this parameter indicates the number of executions done so far including the current one
+//	  and `params` are the exact arguments passed into the underlying function or struct method
+//	returns the same Counter instance to allow setting up further execution expectations
+func (m *mocker) SideEffect(callback func(index int, params ...interface{})) Counter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to SideEffect without setting up an anticipated function or method",
+		)
+		return &counter{m}
+	}
+	m.temp.callback = callback
+	return &counter{m}
+}
+
+// PostSideEffect allows one to setup a callback function that is called after the returns are constructed
+//
+//	note that there is only one post side effect for each mock or stub, and the newest overrides previous ones
+//
+//	callback pass in the customized callback function with an integer parameter `index`
+//	  this parameter indicates the number of executions done so far including the current one
+//	  and `returns` are the exact values returned from the underlying function or struct method
+//	returns the same Counter instance to allow setting up further execution expectations
+func (m *mocker) PostSideEffect(callback func(index int, returns ...interface{})) Counter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to PostSideEffect without setting up an anticipated function or method",
+		)
+		return &counter{m}
+	}
+	m.temp.postback = callback
+	return &counter{m}
+}
+
+// ConditionalReturn allows one to setup a callback function that decides the returns of each call at call time
+//
+//	note that there is only one conditional return for each mock or stub, and the newest overrides previous ones
+//	fn pass in the customized callback function with `args` being the exact arguments passed into
+//	  the underlying function or struct method, returning `use` as true to override the static returns
+//	  with the `returns` given, or false to leave the static returns in effect for the call
+//	returns the same Counter instance to allow setting up further execution expectations
+func (m *mocker) ConditionalReturn(fn func(args []any) (returns []any, use bool)) Counter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to ConditionalReturn without setting up an anticipated function or method",
+		)
+		return &counter{m}
+	}
+	m.temp.override = fn
+	return &counter{m}
+}
+
+// Notify allows one to setup a channel to be notified each time the current mock or stub is executed
+//
+//	the notification is sent after the side effects run and the returns are constructed, before returning to the SUT
+//	note that sending the notification blocks the call until received, unless the channel is buffered
+//	ch pass in the channel to be notified, which receives one notification for each execution
+//	returns the same Counter instance to allow setting up further execution expectations
+func (m *mocker) Notify(ch chan<- struct{}) Counter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to Notify without setting up an anticipated function or method",
+		)
+		return &counter{m}
+	}
+	m.temp.notify = ch
+	return &counter{m}
+}
+
+// TrackCleanup allows one to track the invocations of the func-typed returns of the current mock or stub
+//
+//	e.g. the cleanup closure returned from a function like `func() (resource, func())`
+//	the returned funcs are wrapped to count their invocations, which are then verified using CleanupCalled method
+//	returns the same Counter instance to allow setting up further execution expectations
+func (m *mocker) TrackCleanup() Counter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to TrackCleanup without setting up an anticipated function or method",
+		)
+		return &counter{m}
+	}
+	m.temp.tracking = true
+	return &counter{m}
+}
+
+// trackCleanups wraps the non-nil func-typed returns with the counters of their invocations
+func trackCleanups(results []reflect.Value) ([]reflect.Value, []*atomic.Int64) {
+	var counters []*atomic.Int64
+	var tracked = make([]reflect.Value, 0, len(results))
+	for _, result := range results {
+		if result.Kind() != reflect.Func || result.IsNil() {
+			tracked = append(tracked, result)
+			continue
+		}
+		var original = result
+		var counter = &atomic.Int64{}
+		counters = append(counters, counter)
+		tracked = append(tracked, reflect.MakeFunc(
+			result.Type(),
+			func(args []reflect.Value) []reflect.Value {
+				counter.Add(1)
+				if original.Type().IsVariadic() {
+					return original.CallSlice(args)
+				}
+				return original.Call(args)
+			},
+		))
+	}
+	return tracked, counters
+}
+
+func (e *mockEntry) conditionalReturns(args []reflect.Value) ([]any, bool) {
+	if e.override == nil {
+		return nil, false
+	}
+	var params = make([]any, 0, len(args))
+	for _, arg := range args {
+		params = append(params, arg.Interface())
+	}
+	return e.override(params)
+}
+
+// Return is an alias of Returns for an easier migration from gomock
+func (m *mocker) Return(values ...any) Counter {
+	m.tester.Helper()
+	return m.Returns(values...)
+}
+
+// Do is a gomock style alias of SideEffect for an easier migration from gomock
+//
+//	action pass in the customized callback function having the same parameters as the underlying
+//	  function or struct method, which is called with the exact arguments passed in
+//	returns the same Counter instance to allow setting up further execution expectations
+func (m *mocker) Do(action any) Counter {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to Do without setting up an anticipated function or method",
+		)
+		return &counter{m}
+	}
+	var value = reflect.ValueOf(action)
+	if value.Kind() != reflect.Func {
+		m.fatalf(
+			"function or method [%v] cannot be setup with a non-function action [%v] using Do method",
+			m.current.name,
+			action,
+		)
+		return &counter{m}
+	}
+	return m.SideEffect(func(index int, params ...interface{}) {
+		var args = make([]reflect.Value, 0, len(params))
+		for i, param := range params {
+			var arg = reflect.ValueOf(param)
+			if !arg.IsValid() {
+				arg = reflect.Zero(value.Type().In(i))
+			}
+			args = append(args, arg)
+		}
+		if value.Type().IsVariadic() {
+			value.CallSlice(args)
+		} else {
+			value.Call(args)
+		}
+	})
+}
+
+// Once allows one to quickly setup only once execution for the current mock or stub
+//
+//	this is equivalent to call Times(1)
+func (m *mocker) Once() Expectation {
+	m.tester.Helper()
+	return m.Times(1)
+}
+
+// Once allows one to quickly setup only twice executions for the current mock or stub
+//
+//	this is equivalent to call Times(2)
+func (m *mocker) Twice() Expectation {
+	m.tester.Helper()
+	return m.Times(2)
+}
+
+// Times allows one to setup the number of executions for the current mock or stub
+//
+//	count pass in the number of executions expected, and must be a positive number
+func (m *mocker) Times(count int) Expectation {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to Times without setting up an anticipated function or method",
+		)
+		return m.incomplete()
+	}
+	if count < 0 {
+		m.fatalf(
+			"function or method [%v] cannot be mocked for negative [%v] times",
+			m.current.name,
+			count,
+		)
+		return m.incomplete()
+	} else if count == 0 {
+		m.fatalf(
+			"function or method [%v] cannot be mocked for zero times using Times method."+
+				" Try using NotCalled method instead.",
+			m.current.name,
+		)
+		return m.incomplete()
+	}
+	if !m.validate() {
+		return m.incomplete()
+	}
+	m.current.expect += count
+	for i := 0; i < count; i++ {
+		m.current.mocks = append(m.current.mocks, m.temp)
+	}
+	return m.complete(count)
+}
+
+// TimesIf allows one to setup the number of executions for the current mock or stub depending on a condition
+//
+//	when the condition is false, no execution is expected for the current mock or stub
+//	cond pass in the condition whether the executions are expected, e.g. a flag computed at setup
+//	count pass in the number of executions expected when cond is true, and must be a positive number
+func (m *mocker) TimesIf(cond bool, count int) Expectation {
+	m.tester.Helper()
+	if cond {
+		return m.Times(count)
+	}
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to TimesIf without setting up an anticipated function or method",
+		)
+		return m.incomplete()
+	}
+	if count <= 0 {
+		m.fatalf(
+			"function or method [%v] cannot be mocked for non-positive [%v] times",
+			m.current.name,
+			count,
+		)
+		return m.incomplete()
+	}
+	if !m.validate() {
+		return m.incomplete()
+	}
+	return m.complete(0)
+}
+
+// incomplete returns the expectation of a setup failed to complete, on which any further call is reported
+func (m *mocker) incomplete() Expectation {
+	return &expectation{mocker: m}
+}
+
+func (m *mocker) complete(count int) Expectation {
+	var result = &expectation{
+		mocker: m,
+		entry:  m.current,
+		mock:   m.temp,
+	}
+	m.setups++
+	m.temp.position = m.setups
+	m.temp.times = count
+	m.temp.declared = declaredAt()
+	if !m.current.stub {
+		for i := 0; i < count; i++ {
+			m.declared = append(m.declared, result)
+		}
+	}
+	m.temp = nil
+	m.current = nil
+	return result
+}
+
+func (m *mocker) validateReturns(group int, returns []interface{}) bool {
+	m.tester.Helper()
+	var count = m.current.funcType.NumOut()
+	if count == len(returns) {
+		return m.validateReturnTypes(group, returns)
+	}
+	if group > 0 {
+		m.fatalf(
+			"function or method [%v] cannot be setup with invalid number of returns in sequence group #%v: expect %v, actual %v",
+			m.current.name,
+			group,
+			count,
+			len(returns),
+		)
+	} else {
+		m.fatalf(
+			"function or method [%v] cannot be setup with invalid number of returns: expect %v, actual %v",
+			m.current.name,
 			count,
 			len(returns),
 		)
-		return m.returnZeros(funcType)
 	}
-	var rets = []reflect.Value{}
-	for i, ret := range returns {
-		if ret == nil {
-			rets = append(rets, reflect.Zero(funcType.Out(i)))
-		} else {
-			rets = append(rets, reflect.ValueOf(ret))
-		}
+	return false
+}
+
+func (m *mocker) validateReturnTypes(group int, returns []interface{}) bool {
+	m.tester.Helper()
+	for index, value := range returns {
+		var outType = m.current.funcType.Out(index)
+		var valueType = reflect.TypeOf(value)
+		if valueType == nil || valueType.AssignableTo(outType) {
+			continue
+		}
+		if group > 0 {
+			m.fatalf(
+				"function or method [%v] cannot be setup with invalid type of return #%v in sequence group #%v: expect %v, actual %v",
+				m.current.name,
+				index+1,
+				group,
+				outType,
+				valueType,
+			)
+		} else {
+			m.fatalf(
+				"function or method [%v] cannot be setup with invalid type of return #%v: expect %v, actual %v",
+				m.current.name,
+				index+1,
+				outType,
+				valueType,
+			)
+		}
+		return false
+	}
+	return true
+}
+
+func (m *mocker) validateParameterTypes() bool {
+	m.tester.Helper()
+	var funcType = m.current.funcType
+	var count = funcType.NumIn()
+	for index, value := range m.temp.parameters {
+		if value == nil {
+			continue
+		}
+		var inType reflect.Type
+		if funcType.IsVariadic() && !m.current.asSlice && index >= count-1 {
+			inType = funcType.In(count - 1).Elem()
+		} else if index < count {
+			inType = funcType.In(index)
+		} else {
+			break
+		}
+		if typed, ok := value.(typedParameter); ok {
+			if acceptsType(inType, typed.valueType()) {
+				continue
+			}
+			m.fatalf(
+				"function or method [%v] cannot be setup with matcher of type %v for parameter #%v of type %v",
+				m.current.name,
+				typed.valueType(),
+				index+1,
+				inType,
+			)
+			return false
+		}
+		if _, ok := value.(parameter); ok {
+			continue
+		}
+		var valueType = reflect.TypeOf(value)
+		if valueType.AssignableTo(inType) {
+			continue
+		}
+		m.fatalf(
+			"function or method [%v] cannot be setup with invalid type of parameter #%v: expect %v, actual %v",
+			m.current.name,
+			index+1,
+			inType,
+			valueType,
+		)
+		return false
+	}
+	return true
+}
+
+func (m *mocker) validate() bool {
+	m.tester.Helper()
+	if m.current.funcType == nil {
+		return true
+	}
+	if !m.validateParameterTypes() {
+		return false
+	}
+	if m.temp.channel != nil || m.temp.zero {
+		return true
+	}
+	if m.temp.sequence == nil {
+		return m.validateReturns(0, m.temp.returns)
+	}
+	for index, returns := range m.temp.sequence {
+		if !m.validateReturns(index+1, returns) {
+			return false
+		}
+	}
+	return true
+}
+
+// AtLeast allows one to setup the minimum number of executions for the current mock or stub
+//
+//	calls beyond the minimum are served by the current mock or stub, thus it must be the last setup
+//
+//	count pass in the minimum number of executions expected, and must not be a negative number
+func (m *mocker) AtLeast(count int) Expectation {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to AtLeast without setting up an anticipated function or method",
+		)
+		return m.incomplete()
+	}
+	if count < 0 {
+		m.fatalf(
+			"function or method [%v] cannot be mocked for at least negative [%v] times",
+			m.current.name,
+			count,
+		)
+		return m.incomplete()
+	}
+	if !m.validate() {
+		return m.incomplete()
+	}
+	m.current.expect += count
+	m.current.unbounded = true
+	for i := 0; i < max(count, 1); i++ {
+		m.current.mocks = append(m.current.mocks, m.temp)
+	}
+	return m.complete(count)
+}
+
+// AnyTimes allows one to setup any number of executions for the current mock or stub
+//
+//	this is equivalent to call AtLeast(0)
+func (m *mocker) AnyTimes() Expectation {
+	m.tester.Helper()
+	return m.AtLeast(0)
+}
+
+// InOrder allows one to verify that the given setups are called in the exact order as they are passed in
+//
+//	a setup must be fully called for its expected number of times before its successor is called,
+//	while other setups are not affected
+//
+//	setups pass in the results of Once/Twice/Times/AtLeast/AnyTimes methods from the setups to be ordered
+func (m *mocker) InOrder(setups ...Mocker) {
+	m.tester.Helper()
+	var order = &ordering{}
+	for index, setup := range setups {
+		var expect, ok = setup.(*expectation)
+		if !ok {
+			m.fatalf(
+				"Unexpected setup #%v passed to InOrder, only the results of Once/Twice/Times/AtLeast/AnyTimes are supported",
+				index+1,
+			)
+			return
+		}
+		order.mocks = append(order.mocks, expect.mock)
+		order.names = append(order.names, expect.entry.name)
+	}
+	for _, mock := range order.mocks {
+		mock.orderings = append(mock.orderings, order)
+	}
+}
+
+// After allows one to verify that the current setup is only called after the given setups are fully called
+//
+//	setups pass in the results of Once/Twice/Times/AtLeast/AnyTimes methods from the prerequisite setups
+//	returns the same Expectation instance to allow further setups
+func (e *expectation) After(setups ...Mocker) Expectation {
+	e.tester.Helper()
+	if e.mock == nil {
+		e.fatalf(
+			"Unexpected call to After without completing a setup using Once/Twice/Times/AtLeast/AnyTimes",
+		)
+		return e
+	}
+	for index, setup := range setups {
+		var after, ok = setup.(*expectation)
+		if !ok {
+			e.fatalf(
+				"Unexpected setup #%v passed to After, only the results of Once/Twice/Times/AtLeast/AnyTimes are supported",
+				index+1,
+			)
+			return e
+		}
+		e.mock.afters = append(e.mock.afters, after)
+	}
+	return e
+}
+
+// Requires allows one to verify that the current setup is only called after the given setups' functions are called
+//
+//	unlike After, the prerequisite functions or struct methods only need to be called at least once, not fully
+//
+//	setups pass in the results of Once/Twice/Times/AtLeast/AnyTimes methods from the prerequisite setups
+//	returns the same Expectation instance to allow further setups
+func (e *expectation) Requires(setups ...Mocker) Expectation {
+	e.tester.Helper()
+	if e.mock == nil {
+		e.fatalf(
+			"Unexpected call to Requires without completing a setup using Once/Twice/Times/AtLeast/AnyTimes",
+		)
+		return e
+	}
+	for index, setup := range setups {
+		var required, ok = setup.(*expectation)
+		if !ok {
+			e.fatalf(
+				"Unexpected setup #%v passed to Requires, only the results of Once/Twice/Times/AtLeast/AnyTimes are supported",
+				index+1,
+			)
+			return e
+		}
+		e.mock.requires = append(e.mock.requires, required.entry)
+	}
+	return e
+}
+
+// Sequence creates a named sequence to verify that the setups attached to it are called in their attachment order
+//
+//	setups in different sequences are not ordered relative to each other
+//
+//	name pass in the name of the sequence shown in failure messages, e.g. "read pipeline"
+//	returns a Sequence instance to be attached to setups via In method
+func (m *mocker) Sequence(name string) *Sequence {
+	m.tester.Helper()
+	return &Sequence{
+		order: &ordering{name: name},
+	}
+}
+
+// In attaches the current setup to the given sequence, after all setups previously attached to it
+//
+//	sequence pass in the Sequence instance created by Sequence method
+//	returns the same Expectation instance to allow further setups
+func (e *expectation) In(sequence *Sequence) Expectation {
+	e.tester.Helper()
+	if e.mock == nil {
+		e.fatalf(
+			"Unexpected call to In without completing a setup using Once/Twice/Times/AtLeast/AnyTimes",
+		)
+		return e
+	}
+	if sequence == nil {
+		e.fatalf(
+			"Unexpected nil sequence passed to In, only the results of Sequence method are supported",
+		)
+		return e
+	}
+	var order = sequence.order
+	order.mocks = append(order.mocks, e.mock)
+	order.names = append(order.names, e.entry.name)
+	e.mock.orderings = append(e.mock.orderings, order)
+	return e
+}
+
+func (m *mocker) find(expectFunc interface{}) (*funcEntry, string, bool) {
+	m.tester.Helper()
+	var value = reflect.ValueOf(expectFunc)
+	var funcPtr uintptr
+	var name string
+	if value.Kind() == reflect.Pointer && value.Elem().Kind() == reflect.Func {
+		funcPtr = value.Pointer()
+		name = fmt.Sprint("variable of ", value.Elem().Type())
+	} else {
+		funcPtr, name = m.getFuncPointer(expectFunc)
+	}
+	var entry, found = m.entries[funcPtr]
+	return entry, name, found
+}
+
+func (m *mocker) lookup(expectFunc interface{}) (*funcEntry, bool) {
+	m.tester.Helper()
+	var entry, name, found = m.find(expectFunc)
+	if !found {
+		m.errorf(
+			"The underlying function or method %v was never setup",
+			name,
+		)
+	}
+	return entry, found
+}
+
+// CallCount returns the number of calls to the given function or struct method so far, for both mocks and stubs
+//
+//	expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+//	returns the number of calls, or 0 if the function or struct method was never setup
+func (m *mocker) CallCount(expectFunc interface{}) int {
+	m.tester.Helper()
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	var entry, _, found = m.find(expectFunc)
+	if !found {
+		return 0
+	}
+	return len(entry.history)
+}
+
+// Satisfied returns whether the calls to the given function or struct method so far meet the expected number of calls
+//
+//	this is useful to poll for the calls made asynchronously by the SUT before proceeding with the test
+//
+//	expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+//	returns true if the expected number of calls is met, or false if not or the function or struct method was never setup
+func (m *mocker) Satisfied(expectFunc interface{}) bool {
+	m.tester.Helper()
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	var entry, _, found = m.find(expectFunc)
+	if !found {
+		return false
+	}
+	return countMismatch(entry) == nil
+}
+
+// CleanupCalled returns whether all the func-typed returns tracked by TrackCleanup have been invoked so far
+//
+//	this is useful to verify that the SUT releases the resources acquired from the given function or struct method
+//
+//	expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+//	returns true if every tracked non-nil func returned so far has been invoked at least once, or false otherwise,
+//	  including when nothing has been tracked or the function or struct method was never setup
+func (m *mocker) CleanupCalled(expectFunc interface{}) bool {
+	m.tester.Helper()
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	var entry, _, found = m.find(expectFunc)
+	if !found {
+		return false
+	}
+	var tracked = 0
+	for _, record := range entry.history {
+		for _, counter := range record.cleanups {
+			if counter.Load() == 0 {
+				return false
+			}
+			tracked++
+		}
+	}
+	return tracked > 0
+}
+
+// WaitForCall blocks until the number of calls to the given function or struct method reaches the count
+//
+//	the test fails with the current number of calls if the count is not reached before the timeout elapses
+//	this is useful to wait for the calls made asynchronously by the SUT, and can be used repeatedly for each phase
+//
+//	expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+//	count pass in the total number of calls to wait for, for both mocks and stubs
+//	timeout pass in the maximum duration to wait
+//	returns true if the count is reached, or false otherwise
+func (m *mocker) WaitForCall(expectFunc interface{}, count int, timeout time.Duration) bool {
+	m.tester.Helper()
+	var timer = time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		m.locker.Lock()
+		var entry, found = m.lookup(expectFunc)
+		if !found {
+			m.locker.Unlock()
+			return false
+		}
+		var actual = len(entry.history)
+		if actual >= count {
+			m.locker.Unlock()
+			return true
+		}
+		if m.called == nil {
+			m.called = make(chan struct{})
+		}
+		var called = m.called
+		m.locker.Unlock()
+		select {
+		case <-called:
+		case <-timer.C:
+			m.locker.Lock()
+			actual = len(entry.history)
+			m.locker.Unlock()
+			if actual >= count {
+				return true
+			}
+			m.errorf(
+				"[%v] Timed out waiting for calls after %v: expect %v, actual %v",
+				entry.name,
+				timeout,
+				count,
+				actual,
+			)
+			return false
+		}
+	}
+}
+
+// TotalCalls returns the number of calls to all functions or struct methods setup so far, for both mocks and stubs
+//
+//	returns the total number of calls made to this mocker
+func (m *mocker) TotalCalls() int {
+	m.tester.Helper()
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	var total = 0
+	for _, entry := range m.allEntries() {
+		total += len(entry.history)
+	}
+	return total
+}
+
+// AssertCalledWith verifies that at least one call to the given function or struct method so far matches the parameters
+//
+//	this is useful when the expected parameters are only known after executing the SUT
+//
+//	expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+//	parameters pass in the list of parameters to be verified, just like how they are passed into Expects method
+func (m *mocker) AssertCalledWith(expectFunc interface{}, parameters ...any) {
+	m.tester.Helper()
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	var entry, found = m.lookup(expectFunc)
+	if !found {
+		return
+	}
+	if len(entry.history) == 0 {
+		m.errorf(
+			"[%v] Expect at least one call with parameters %v, actual no call",
+			entry.name,
+			parameters,
+		)
+		return
+	}
+	var closest = 0
+	var closestDiffs []string
+	for index, record := range entry.history {
+		var diffs = m.diffParameters(entry, parameters, record.args)
+		if len(diffs) == 0 {
+			return
+		}
+		if closestDiffs == nil || len(diffs) < len(closestDiffs) {
+			closest = index + 1
+			closestDiffs = diffs
+		}
+	}
+	m.errorf(
+		"[%v] Expect at least one call with parameters %v, closest call #%v %v: %v",
+		entry.name,
+		parameters,
+		closest,
+		interfaceArguments(entry.funcType, entry.history[closest-1].args),
+		strings.Join(closestDiffs, "; "),
+	)
+}
+
+// AssertNotCalledWith verifies that no call to the given function or struct method so far matches the parameters
+//
+//	expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+//	parameters pass in the list of parameters to be verified, just like how they are passed into Expects method
+func (m *mocker) AssertNotCalledWith(expectFunc interface{}, parameters ...any) {
+	m.tester.Helper()
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	var entry, found = m.lookup(expectFunc)
+	if !found {
+		return
+	}
+	for index, record := range entry.history {
+		if m.matchParameters(entry, parameters, record.args) {
+			m.errorf(
+				"[%v] Expect no call with parameters %v, actual call #%v %v",
+				entry.name,
+				parameters,
+				index+1,
+				interfaceArguments(entry.funcType, record.args),
+			)
+			return
+		}
+	}
+}
+
+// AssertNothingCalled verifies that no function or struct method setup so far has been called, for both mocks and stubs
+//
+//	this is useful when the SUT is expected to short-circuit before touching any of its dependencies
+func (m *mocker) AssertNothingCalled() {
+	m.tester.Helper()
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	var called = []string{}
+	for _, entry := range m.allEntries() {
+		if len(entry.history) > 0 {
+			called = append(called, fmt.Sprintf("[%v] x%v", entry.name, len(entry.history)))
+		}
+	}
+	if len(called) > 0 {
+		m.errorf(
+			"Expect no call to any function or method, actual calls to %v",
+			strings.Join(called, ", "),
+		)
+	}
+}
+
+// Verify verifies all mocks setup so far immediately, instead of waiting for the end of test
+//
+//	the verified mocks are not verified again at the end of test, while the patches are kept in place,
+//	so that new mocks can be setup for the same functions or struct methods in the next phase of test
+func (m *mocker) Verify() {
+	m.tester.Helper()
+	m.awaitEntries()
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	m.verifyEntries()
+}
+
+func (m *mocker) allEntries() []*funcEntry {
+	var entries = make([]*funcEntry, 0, len(m.entries))
+	for _, entry := range m.entries {
+		entries = append(entries, entry)
+		for _, instance := range entry.instances {
+			entries = append(entries, instance)
+		}
+	}
+	slices.SortFunc(entries, func(a, b *funcEntry) int {
+		return a.order - b.order
+	})
+	return entries
+}
+
+// Labeled allows one to attach a label to the current setup shown in its failure messages along with the name
+//
+//	label pass in the human-readable label of the setup, e.g. "cache miss case"
+//	returns the same Expecter instance to allow setting up parameter expectations
+func (m *mocker) Labeled(label string) Expecter {
+	m.tester.Helper()
+	m.label(label)
+	return m
+}
+
+// Labeled allows one to attach a label to the current setup shown in its failure messages along with the name
+//
+//	label pass in the human-readable label of the setup, e.g. "cache miss case"
+//	returns the same Returner instance to allow setting up return expectations
+func (r *returner) Labeled(label string) Returner {
+	r.tester.Helper()
+	r.label(label)
+	return r
+}
+
+// Labeled allows one to attach a label to the current setup shown in its failure messages along with the name
+//
+//	label pass in the human-readable label of the setup, e.g. "cache miss case"
+//	returns the same Counter instance to allow setting up further execution expectations
+func (c *counter) Labeled(label string) Counter {
+	c.tester.Helper()
+	c.label(label)
+	return c
+}
+
+func (m *mocker) label(label string) {
+	m.tester.Helper()
+	if m.current == nil || m.temp == nil {
+		m.fatalf(
+			"Unexpected call to Labeled without setting up an anticipated function or method",
+		)
+		return
+	}
+	m.temp.label = label
+}
+
+// Labeled allows one to attach a label to the current setup shown in its failure messages along with the name
+//
+//	label pass in the human-readable label of the setup, e.g. "cache miss case"
+//	returns the same Expectation instance to allow further setups
+func (e *expectation) Labeled(label string) Expectation {
+	e.tester.Helper()
+	if e.mock == nil {
+		e.fatalf(
+			"Unexpected call to Labeled without completing a setup using Once/Twice/Times/AtLeast/AnyTimes",
+		)
+		return e
+	}
+	e.mock.label = label
+	return e
+}
+
+// Within allows one to wait for the expected number of calls to the current setup at verification
+//
+//	the calls made asynchronously by the SUT after the test body ends are counted, until the timeout elapses
+//	this applies to all setups of the underlying function or struct method
+//
+//	timeout pass in the maximum duration to wait at verification for the expected number of calls
+//	returns the same Expectation instance to allow further setups
+func (e *expectation) Within(timeout time.Duration) Expectation {
+	e.tester.Helper()
+	if e.mock == nil {
+		e.fatalf(
+			"Unexpected call to Within without completing a setup using Once/Twice/Times/AtLeast/AnyTimes",
+		)
+		return e
 	}
-	return rets
+	e.entry.within = timeout
+	return e
 }
 
-func (m *mocker) makeFunc(name string, funcPtr uintptr, funcType reflect.Type) reflect.Value {
-	m.tester.Helper()
-	return reflect.MakeFunc(
-		funcType,
-		func(args []reflect.Value) []reflect.Value {
-			m.tester.Helper()
-			defer m.recover(name)
-			var entry, found = m.entries[funcPtr]
-			if !found {
-				m.tester.Fatalf(
-					"The underlying function or method %v was never setup",
-					name,
-				)
-				return nil
-			}
-			entry.actual++
-			if entry.actual > entry.expect || entry.actual > len(entry.mocks) {
-				if !entry.stub {
-					m.tester.Errorf(
-						"[%v] Unepxected number of calls: expect %v, actual %v",
-						name,
-						entry.expect,
-						entry.actual,
-					)
-					entry.verified = true
-					return m.returnZeros(funcType)
-				}
-				entry.actual = len(entry.mocks)
-			}
-			var mock = entry.mocks[entry.actual-1]
-			if !entry.stub {
-				if funcType.IsVariadic() {
-					m.compareVariadicParameters(name, entry.actual, mock.parameters, args)
-				} else {
-					m.compareNormalParameters(name, entry.actual, mock.parameters, args)
-				}
+// awaitEntries waits for the entries setup using Within to reach their expected number of calls
+//
+//	each entry is waited for up to its own timeout, counting from the start of the verification
+func (m *mocker) awaitEntries() {
+	var start = time.Now()
+	for {
+		m.locker.Lock()
+		var remaining = time.Duration(-1)
+		for _, entry := range m.allEntries() {
+			var left = entry.within - time.Since(start)
+			if entry.verified || entry.stub || entry.actual >= entry.expect || left <= 0 {
+				continue
 			}
-			if mock.callback != nil {
-				var params = []interface{}{}
-				for _, arg := range args {
-					params = append(params, arg.Interface())
-				}
-				mock.callback(entry.actual, params...)
+			if remaining < 0 || left < remaining {
+				remaining = left
 			}
-			return m.constructReturns(name, entry.actual, funcType, mock.returns)
-		},
-	)
+		}
+		if remaining < 0 {
+			m.locker.Unlock()
+			return
+		}
+		if m.called == nil {
+			m.called = make(chan struct{})
+		}
+		var called = m.called
+		m.locker.Unlock()
+		select {
+		case <-called:
+		case <-time.After(remaining):
+		}
+	}
 }
 
-func (m *mocker) setup(name string, stub bool, funcPtr uintptr) {
+func (m *mocker) verifyEntries() {
 	m.tester.Helper()
-	if m.current != nil || m.temp != nil {
-		m.tester.Fatalf(
-			"A former setup for function or method [%v] was incomplete."+
-				" Did you miss calling the Once/Twice/Times method in the end?",
-			name,
-		)
-		return
+	for _, entry := range m.allEntries() {
+		var blocked = entry.blocked.Load()
+		if blocked > 0 {
+			m.errorf(
+				"[%v] Calls still blocked waiting for returns from channel at verification: %v",
+				entry.name,
+				blocked,
+			)
+		}
+		if entry.verified || entry.stub {
+			continue
+		}
+		entry.verified = true
+		for _, result := range m.entryMismatches(entry) {
+			m.errorf(result.format, result.args...)
+		}
 	}
-	var entry, found = m.entries[funcPtr]
-	if found {
-		if entry.stub != stub {
-			if entry.stub {
-				m.tester.Fatalf(
-					"A former setup for function or method [%v] was a Stub but current setup is a Mock."+
-						" We do not support mixing Stub and Mock for the same function or method at the moment.",
-					name,
-				)
-			} else {
-				m.tester.Fatalf(
-					"A former setup for function or method [%v] was a Mock but current setup is a Stub."+
-						" We do not support mixing Stub and Mock for the same function or method at the moment.",
-					name,
-				)
-			}
-			return
+}
+
+// entryMismatches runs the checks of verification on the mock entry without reporting them, in the order of reporting
+func (m *mocker) entryMismatches(entry *funcEntry) []*mismatch {
+	var results = []*mismatch{}
+	if len(entry.anyOrders) > 0 {
+		if result := m.anyOrderMismatch(entry); result != nil {
+			results = append(results, result)
 		}
-		if entry.nocall {
-			m.tester.Fatalf("A former setup for function or method [%v] was to be not called,"+
-				" therefore no more Mock or Stub can be setup for it now.",
-				name,
-			)
-			return
+	}
+	if entry.distinct {
+		results = append(results, distinctMismatches(entry)...)
+	}
+	if result := countMismatch(entry); result != nil {
+		results = append(results, result)
+	}
+	return results
+}
+
+func countMismatch(entry *funcEntry) *mismatch {
+	var pending = entry.pending()
+	if entry.unbounded {
+		if entry.actual >= entry.expect {
+			return nil
 		}
-		m.current = entry
-		m.temp = &mockEntry{}
-		return
+		return withDeclared(&mismatch{
+			format: "[%v] Unepxected number of calls: expect at least %v, actual %v",
+			args:   []interface{}{pending.display(entry.name), entry.expect, entry.actual},
+		}, pending.declared)
 	}
-	entry = &funcEntry{
-		name:   name,
-		stub:   stub,
-		actual: 0,
-		mocks:  make([]*mockEntry, 0),
+	if entry.expect == entry.actual {
+		return nil
 	}
-	m.entries[funcPtr] = entry
-	m.current = entry
-	m.temp = &mockEntry{}
+	return withDeclared(&mismatch{
+		format: "[%v] Unepxected number of calls: expect %v, actual %v",
+		args:   []interface{}{pending.display(entry.name), entry.expect, entry.actual},
+	}, pending.declared)
 }
 
-// Mock allows one to mock either a function or a struct method visible to the current package
+func (e *funcEntry) pending() *mockEntry {
+	for _, mock := range e.mocks {
+		if mock.used < mock.times {
+			return mock
+		}
+	}
+	if len(e.mocks) == 0 {
+		return &mockEntry{}
+	}
+	return e.mocks[len(e.mocks)-1]
+}
+
+func (e *mockEntry) display(name string) string {
+	if e.label == "" {
+		return name
+	}
+	return fmt.Sprintf("%v / '%v'", name, e.label)
+}
+
+// ExpectationsWereMet verifies all mocks setup so far the same way as at the end of test, without reporting to the tester
 //
-//	expectFunc pass in the pointer to the function to be mocked
-//	returns an Expecter instance to allow setting up parameter expectations
-func (m *mocker) Mock(expectFunc interface{}) Expecter {
+//	returns an error joining the descriptions of all failures in the order of setups followed by the calls out of order, or nil if all are met
+func (m *mocker) ExpectationsWereMet() error {
 	m.tester.Helper()
 	m.locker.Lock()
 	defer m.locker.Unlock()
-	var funcPtr, name = m.getFuncPointer(expectFunc)
-	var funcType = reflect.TypeOf(expectFunc)
-	m.setup(name, false, funcPtr)
-	m.patches.ApplyCore(
-		reflect.ValueOf(expectFunc),
-		m.makeFunc(name, funcPtr, funcType),
-	)
-	return m
+	var errs = []error{}
+	for _, entry := range m.allEntries() {
+		if blocked := entry.blocked.Load(); blocked > 0 {
+			errs = append(errs, fmt.Errorf(
+				"[%v] Calls still blocked waiting for returns from channel at verification: %v",
+				entry.name,
+				blocked,
+			))
+		}
+		if entry.stub {
+			continue
+		}
+		for _, result := range m.entryMismatches(entry) {
+			errs = append(errs, fmt.Errorf(result.format, result.args...))
+		}
+	}
+	for _, result := range m.disorders {
+		errs = append(errs, fmt.Errorf(result.format, result.args...))
+	}
+	return errors.Join(errs...)
 }
 
-// Stub allows one to stub either a function or a struct method visible to the current package
+// UnmetExpectations returns the names of all mocks setup so far whose number of calls is not as expected
 //
-//	expectFunc pass in the pointer to the function to be mocked
-//	returns a Returner instance to allow setting up return expectations
-func (m *mocker) Stub(expectFunc interface{}) Returner {
+//	returns the list of names in the order of setups, or an empty list if all are met
+func (m *mocker) UnmetExpectations() []string {
 	m.tester.Helper()
 	m.locker.Lock()
 	defer m.locker.Unlock()
-	var funcPtr, name = m.getFuncPointer(expectFunc)
-	var funcType = reflect.TypeOf(expectFunc)
-	m.setup(name, true, funcPtr)
-	m.patches.ApplyCore(
-		reflect.ValueOf(expectFunc),
-		m.makeFunc(name, funcPtr, funcType),
-	)
-	return m
+	var names = []string{}
+	for _, entry := range m.unmetEntries() {
+		names = append(names, entry.name)
+	}
+	return names
 }
 
-// Expects allows one to setup a list of parameters to be verified during a function or a struct method call
+// pendingSize is the maximum number of functions or methods listed when summarizing pending expectations
+const pendingSize = 5
+
+// withPending appends a summary of the functions or methods still expecting calls, only once per test
 //
-//	parameters pass in the list of parameters to be verified,
-//	  just like how they are normally passed into the original function or struct method
-//	returns a Returner instance to allow setting up return expectations
-func (m *mocker) Expects(parameters ...any) Returner {
-	m.tester.Helper()
-	if m.current == nil || m.temp == nil {
-		m.tester.Fatalf(
-			"Unexpected call to Expects without setting up an anticipated function or method",
-		)
-		return m
+//	an unexpected call often means the SUT took a different branch, which is revealed by the calls never made
+func (m *mocker) withPending(result *mismatch) *mismatch {
+	if m.summarized {
+		return result
+	}
+	var lines = []string{}
+	var remaining = 0
+	for _, entry := range m.allEntries() {
+		if entry.stub || entry.actual >= entry.expect {
+			continue
+		}
+		remaining++
+		if len(lines) < pendingSize {
+			lines = append(lines, fmt.Sprintf("[%v] %v more call(s)", entry.name, entry.expect-entry.actual))
+		}
+	}
+	if remaining == 0 {
+		return result
+	}
+	if remaining > len(lines) {
+		lines = append(lines, fmt.Sprintf("... and %v more", remaining-len(lines)))
+	}
+	m.summarized = true
+	return &mismatch{
+		format: result.format + "\nStill pending:\n\t%v",
+		args:   append(result.args, strings.Join(lines, "\n\t")),
 	}
-	m.temp.parameters = parameters
-	return m
 }
 
-// NotCalled verifies that no call is expected to the underlying function or struct method
-//
-//	the underlying function or struct method cannot be mocked or stubbed again in the same test
-//	this completes the current Mock sequence, as well as overrides any previous mock or stub
-func (m *mocker) NotCalled() {
-	m.tester.Helper()
-	if m.current == nil || m.temp == nil {
-		m.tester.Fatalf(
-			"Unexpected call to NotCalled without setting up an anticipated function or method",
-		)
-		return
+func (m *mocker) unmetEntries() []*funcEntry {
+	var entries = []*funcEntry{}
+	for _, entry := range m.allEntries() {
+		if !entry.stub && countMismatch(entry) != nil {
+			entries = append(entries, entry)
+		}
 	}
-	m.current.nocall = true
-	m.current.expect = 0
-	m.current.mocks = []*mockEntry{{}}
-	m.temp = nil
-	m.current = nil
+	return entries
 }
 
-// Returns allows one to setup a list of values to be returned after a function or a struct method call
+// CallsOf returns the calls to the given function or struct method so far, for both mocks and stubs
 //
-//	values pass in the list of values to be returned,
-//	  just like how they are normally returned from the original function or struct method
-//	returns a Counter instance to allow setting up execution expectations
-func (m *mocker) Returns(values ...any) Counter {
+//	this is useful to verify the relationships between calls after executing the SUT
+//
+//	expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+//	returns the list of calls in the order they are made
+func (m *mocker) CallsOf(expectFunc interface{}) []Call {
 	m.tester.Helper()
-	if m.current == nil || m.temp == nil {
-		m.tester.Fatalf(
-			"Unexpected call to Returns without setting up an anticipated function or method",
-		)
-		return m
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	var entry, found = m.lookup(expectFunc)
+	if !found {
+		return nil
 	}
-	m.temp.returns = values
-	return m
+	var calls = make([]Call, 0, len(entry.history))
+	for index, record := range entry.history {
+		var call = Call{
+			Index:   index + 1,
+			Args:    []any{},
+			Returns: []any{},
+		}
+		for _, arg := range record.args {
+			call.Args = append(call.Args, arg.Interface())
+		}
+		for _, value := range record.returns {
+			call.Returns = append(call.Returns, value.Interface())
+		}
+		calls = append(calls, call)
+	}
+	return calls
 }
 
-// SideEffect allows one to setup a callback function that is called during expectation verification
+// ReturnedValues returns the values returned to the callers of the given function or struct method so far
 //
-//	note that there is only one side effect for each mock or stub, and the newest overrides previous ones
+//	this is useful to verify the dynamic returns, e.g. from ConditionalReturn, produced the expected values
 //
-//	callback pass in the customized callback function with an integer parameter `index`
-//	  this parameter indicates the number of executions done so far including the current one
-//	  and `params` are the exact arguments passed into the underlying function or struct method
-//	returns the same Counter instance to allow setting up further execution expectations
-func (m *mocker) SideEffect(callback func(index int, params ...interface{})) Counter {
+//	expectFunc pass in the function or struct method setup previously, or the pointer to the function variable
+//	returns the list of returned values for each call in the order they are made
+func (m *mocker) ReturnedValues(expectFunc interface{}) [][]any {
 	m.tester.Helper()
-	if m.current == nil || m.temp == nil {
-		m.tester.Fatalf(
-			"Unexpected call to SideEffect without setting up an anticipated function or method",
-		)
-		return m
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	var entry, found = m.lookup(expectFunc)
+	if !found {
+		return nil
 	}
-	m.temp.callback = callback
-	return m
+	var values = make([][]any, 0, len(entry.history))
+	for _, record := range entry.history {
+		var returns = []any{}
+		for _, value := range record.returns {
+			returns = append(returns, value.Interface())
+		}
+		values = append(values, returns)
+	}
+	return values
 }
 
-// Once allows one to quickly setup only once execution for the current mock or stub
+// Close verifies all mocks and resets all patches immediately, instead of waiting for the end of test
 //
-//	this is equivalent to call Times(1)
-func (m *mocker) Once() Mocker {
+//	this is safe to be deferred or called multiple times, as only the first call takes effect,
+//	which is useful for a mocker whose tester does not run the cleanup functions
+func (m *mocker) Close() {
 	m.tester.Helper()
-	return m.Times(1)
+	m.verifyAll()
 }
 
-// Once allows one to quickly setup only twice executions for the current mock or stub
+// Scope creates a child mocker for a subtest, whose mocks are verified and patches are reset at the end of the subtest
 //
-//	this is equivalent to call Times(2)
-func (m *mocker) Twice() Mocker {
+//	the child mocker shares no setups or patches with the current one, but is created with the same options
+//
+//	tester simply pass in the Golang testing struct from the subtest method
+//	returns the child mocker to setup the mocks or stubs only effective during the subtest
+func (m *mocker) Scope(tester testing.TB) Mocker {
 	m.tester.Helper()
-	return m.Times(2)
+	return NewMocker(tester, m.options...)
 }
 
-// Times allows one to setup the number of executions for the current mock or stub
+// Derive creates a child mocker falling back to the setups of the current one, whose own setups are verified independently
 //
-//	count pass in the number of executions expected, and must be a positive number
-func (m *mocker) Times(count int) Mocker {
+//	the calls to the functions or struct methods not setup by the child are served and recorded by the current mocker,
+//	while a setup in the child takes over the calls to its function or struct method until the child is verified
+//
+//	returns the child mocker to setup further mocks or stubs
+func (m *mocker) Derive() Mocker {
 	m.tester.Helper()
-	if m.current == nil || m.temp == nil {
-		m.tester.Fatalf(
-			"Unexpected call to Times without setting up an anticipated function or method",
-		)
-		return m
-	}
-	if count < 0 {
-		m.tester.Fatalf(
-			"function or method [%v] cannot be mocked for negative [%v] times",
-			m.current.name,
-			count,
-		)
-		return m
-	} else if count == 0 {
-		m.tester.Fatalf(
-			"function or method [%v] cannot be mocked for zero times using Times method."+
-				" Try using NotCalled method instead.",
-			m.current.name,
-		)
-		return m
+	var child = NewMocker(m.tester, m.options...).(*mocker)
+	child.parent = m
+	return child
+}
+
+// forward passes a call not setup by a derived mocker on to its parent, as if the patch of the parent was in place
+func (m *mocker) forward(name string, funcPtr uintptr, funcType reflect.Type, args []reflect.Value) []reflect.Value {
+	m.tester.Helper()
+	var double = m.makeFunc(name, funcPtr, funcType)
+	if funcType.IsVariadic() {
+		return double.CallSlice(args)
 	}
-	m.current.expect += count
-	for i := 0; i < count; i++ {
-		m.current.mocks = append(m.current.mocks, m.temp)
+	return double.Call(args)
+}
+
+func (m *mocker) verifyUnusedStubs() {
+	m.tester.Helper()
+	for _, entry := range m.allEntries() {
+		if !entry.stub || entry.actual > 0 {
+			continue
+		}
+		var result = withDeclared(&mismatch{
+			format: "[%v] Stub was never called",
+			args:   []interface{}{entry.name},
+		}, entry.pending().declared)
+		m.errorf(result.format, result.args...)
 	}
-	m.temp = nil
-	m.current = nil
-	return m
 }
 
 func (m *mocker) verifyAll() {
 	m.tester.Helper()
-	for _, entry := range m.entries {
-		if entry.verified {
-			continue
+	if !m.manual {
+		m.awaitEntries()
+	}
+	m.locker.Lock()
+	defer m.locker.Unlock()
+	if m.closed {
+		return
+	}
+	m.closed = true
+	if m.current != nil && m.temp != nil && !m.aborted.Load() {
+		m.errorf(
+			"A former setup for function or method [%v] was incomplete."+
+				" Did you miss calling the Once/Twice/Times method in the end?",
+			m.current.name,
+		)
+	}
+	if !m.manual {
+		m.verifyEntries()
+		if m.unused {
+			m.verifyUnusedStubs()
 		}
-		if !entry.stub && entry.expect != entry.actual {
-			m.tester.Errorf(
-				"[%v] Unepxected number of calls: expect %v, actual %v",
-				entry.name,
-				entry.expect,
-				entry.actual,
-			)
+		m.reportTimeline()
+	}
+	if m.done != nil {
+		select {
+		case <-m.done:
+		default:
+			close(m.done)
 		}
 	}
 	m.entries = make(map[uintptr]*funcEntry)
-	m.patches.Reset()
+	m.resetPatches()
 }
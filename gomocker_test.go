@@ -1,11 +1,24 @@
 package gomocker
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"math"
 	"math/rand"
 	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/agiledragon/gomonkey/v2"
 )
 
 func assertEquals(t *testing.T, expect interface{}, actual interface{}, message string) {
@@ -664,6 +677,8 @@ type tester struct {
 	t      *testing.T
 	errorf func(string, ...interface{})
 	fatalf func(string, ...interface{})
+	logf   func(string, ...interface{})
+	name   string
 }
 
 func (t *tester) Errorf(format string, args ...interface{}) {
@@ -674,6 +689,14 @@ func (t *tester) Fatalf(format string, args ...interface{}) {
 	t.fatalf(format, args...)
 }
 
+func (t *tester) Logf(format string, args ...interface{}) {
+	if t.logf == nil {
+		t.t.Logf(format, args...)
+		return
+	}
+	t.logf(format, args...)
+}
+
 func (t *tester) Cleanup(f func()) {
 	t.t.Cleanup(f)
 }
@@ -682,6 +705,13 @@ func (t *tester) Helper() {
 	t.t.Helper()
 }
 
+func (t *tester) Name() string {
+	if t.name != "" {
+		return t.name
+	}
+	return t.t.Name()
+}
+
 func TestMocker_ShouldReportTestFailureWhenMockFunctionIsNotCalledButExpected(t *testing.T) {
 	// arrange
 	var foo = func(bar int) int {
@@ -694,12 +724,12 @@ func TestMocker_ShouldReportTestFailureWhenMockFunctionIsNotCalledButExpected(t
 
 	// expect
 	tester.errorf = func(format string, args ...interface{}) {
-		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v", format, "tester.Errorf called with different message")
-		assertEquals(t, 3, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 4, len(args), "tester.Errorf called with different number of args")
 		assertEquals(t, 1, args[1], "tester.Errorf called with different argument 2")
 		assertEquals(t, 0, args[2], "tester.Errorf called with different argument 3")
 	}
-	m.Mock(foo).Expects().Returns().Once()
+	m.Mock(foo).Expects(Anything()).Returns(0).Once()
 }
 
 func TestMocker_ShouldReportTestFailureWhenMockFunctionIsCalledButNotExpected(t *testing.T) {
@@ -712,8 +742,8 @@ func TestMocker_ShouldReportTestFailureWhenMockFunctionIsCalledButNotExpected(t
 
 	// expect
 	tester.errorf = func(format string, args ...interface{}) {
-		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v", format, "tester.Fatalf called with different message")
-		assertEquals(t, 3, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v with arguments (%v)", format, "tester.Fatalf called with different message")
+		assertEquals(t, 4, len(args), "tester.Fatalf called with different number of args")
 		assertEquals(t, 0, args[1], "tester.Fatalf called with different argument 2")
 		assertEquals(t, 1, args[2], "tester.Fatalf called with different argument 3")
 	}
@@ -725,6 +755,190 @@ func TestMocker_ShouldReportTestFailureWhenMockFunctionIsCalledButNotExpected(t
 	foo()
 }
 
+func TestMocker_ShouldReportTestFailureWhenMockFunctionIsCalledButExpectedNever(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v with arguments (%v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 4, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 0, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, 1, args[2], "tester.Errorf called with different argument 3")
+	}
+
+	// SUT
+	m.Mock(foo).Never()
+
+	// act
+	foo()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldAllowMockAgainAfterNever(t *testing.T) {
+	// arrange
+	var foo = func(int) int { return 0 }
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	m.Mock(foo).Never()
+	assertEquals(t, nil, m.ExpectationsWereMet(), "expectations of never different")
+	m.Mock(foo).Expects(1).Returns(2).Times(1)
+	assertEquals(t, false, m.ExpectationsWereMet() == nil, "expectations of mock different")
+
+	// SUT + act
+	var result = foo(1)
+
+	// assert
+	assertEquals(t, 2, result, "result different")
+	assertEquals(t, nil, m.ExpectationsWereMet(), "expectations after call different")
+	m.verifyAll()
+	assertEquals(t, false, m.failed.Load(), "failed different")
+}
+
+func TestMocker_ShouldAllowStubAgainAfterNever(t *testing.T) {
+	// arrange
+	var foo = func(int) int { return 0 }
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Never()
+	m.Stub(foo).Returns(2).AnyTimes()
+
+	// SUT + act
+	var result1 = foo(1)
+	var result2 = foo(3)
+
+	// assert
+	assertEquals(t, 2, result1, "result1 different")
+	assertEquals(t, 2, result2, "result2 different")
+}
+
+func TestMocker_ShouldListPendingExpectationsOnceWhenCalledMoreThanExpected(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var bar = func() {}
+	var tester = &tester{t: t}
+	var messages = []string{}
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}
+	m.Mock(foo).Named("foo").Expects().Returns().Once()
+	m.Mock(bar).Named("bar").Expects().Returns().Twice()
+
+	// SUT + act
+	foo()
+	foo()
+	foo()
+
+	// assert
+	assertEquals(t, 2, len(messages), "tester.Errorf called with different times")
+	assertEquals(t, true, strings.HasSuffix(messages[0], ")\nStill pending:\n\t[bar] 2 more call(s)"), "first message different")
+	assertEquals(t, false, strings.Contains(messages[1], "Still pending"), "second message different")
+	tester.errorf = func(format string, args ...interface{}) {}
+}
+
+func TestMocker_ShouldReportArgumentsWhenVariadicFunctionIsCalledMoreThanExpected(t *testing.T) {
+	// arrange
+	var foo = func(string, ...int) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v with arguments (%v) (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 5, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 2, args[2], "tester.Errorf called with different argument 3")
+		assertEquals(t, "b, 1, 2, 3", args[3], "tester.Errorf called with different argument 4")
+	}
+	m.Mock(foo).Expects("a", 1).Returns().Once()
+
+	// SUT + act
+	foo("a", 1)
+	foo("b", 1, 2, 3)
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func testCallFoo(foo func()) {
+	foo()
+}
+
+func TestMocker_ShouldReportCallersOfSurplusCallsWithCallerInfo(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+	var messages = []string{}
+
+	// mock
+	var m = NewMocker(tester, WithCallerInfo())
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}
+	m.Mock(foo).Expects().Returns().Once()
+
+	// SUT + act
+	foo()
+	var _, _, line, _ = runtime.Caller(0)
+	foo()
+
+	// assert
+	assertEquals(t, 1, len(messages), "tester.Errorf called with different times")
+	assertEquals(t, true, strings.HasSuffix(messages[0], fmt.Sprintf("\nsurplus calls from:\n\tcall #2: gomocker_test.go:%v", line+1)), "message different")
+}
+
+func TestMocker_ShouldReportCallerStacksOfSurplusCallsWithCallerDepth(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+	var messages = []string{}
+
+	// mock
+	var m = NewMocker(tester, WithCallerDepth(2))
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}
+	m.Mock(foo).Expects().Returns().Once()
+
+	// SUT + act
+	foo()
+	var _, _, line, _ = runtime.Caller(0)
+	testCallFoo(foo)
+
+	// assert
+	assertEquals(t, 1, len(messages), "tester.Errorf called with different times")
+	var callers = messages[0][strings.LastIndex(messages[0], "call #2: ")+len("call #2: "):]
+	assertEquals(t, true, strings.HasPrefix(callers, "gomocker_test.go:"), "first caller different")
+	assertEquals(t, true, strings.HasSuffix(callers, fmt.Sprintf(" <- gomocker_test.go:%v", line+1)), "second caller different")
+}
+
 func TestMocker_ShouldReportTestFailureWhenMockFunctionPanicsWithErrorInExecution(t *testing.T) {
 	defer func() {
 		recover()
@@ -751,6 +965,76 @@ func TestMocker_ShouldReportTestFailureWhenMockFunctionPanicsWithErrorInExecutio
 	foo()
 }
 
+func TestMocker_ShouldReportStackWhenMockFunctionPanicsWithPanicStack(t *testing.T) {
+	defer func() {
+		recover()
+	}()
+
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester, WithPanicStack())
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Mocker panicing recovered: %v\n%s", format, "tester.Errorf called with different message")
+		assertEquals(t, 3, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "paniced", args[1], "tester.Errorf called with different argument 2")
+		var stack = string(args[2].([]byte))
+		assertEquals(t, true, strings.Contains(stack, "TestMocker_ShouldReportStackWhenMockFunctionPanicsWithPanicStack.func"), "tester.Errorf called with different stack")
+		assertEquals(t, true, strings.Contains(stack, "gomocker_test.go:"), "tester.Errorf called with different stack frame")
+	}
+	m.Mock(foo).Expects().Returns().SideEffect(func(index int, params ...interface{}) {
+		panic(errors.New("paniced"))
+	}).Once()
+
+	// SUT + act
+	foo()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldPanicOnOverCallWithPanicOnOverCall(t *testing.T) {
+	// arrange
+	var foo = func() int { return 0 }
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+	var recovered any
+	var stack string
+
+	// mock
+	var m = NewMocker(tester, WithPanicOnOverCall())
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+	}
+	m.Mock(foo).Named("foo").Expects().Returns(1).Once()
+
+	// SUT
+	var result = foo()
+
+	// act
+	func() {
+		defer func() {
+			recovered = recover()
+			stack = string(debug.Stack())
+		}()
+		foo()
+	}()
+
+	// assert
+	assertEquals(t, 1, result, "foo call result different")
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+	assertEquals(t, overCall("[foo] Unepxected number of calls: expect 1, actual 2"), recovered, "recovered panic different")
+	assertEquals(t, true, strings.Contains(stack, "TestMocker_ShouldPanicOnOverCallWithPanicOnOverCall.func"), "recovered stack different")
+}
+
 func TestMocker_ShouldReportTestFailureWhenMockFunctionPanicsWithMessageInExecution(t *testing.T) {
 	defer func() {
 		recover()
@@ -788,8 +1072,8 @@ func TestMocker_ShouldReportTestFailureWhenMockFunctionParameterValueNotEqual(t
 
 	// expect
 	tester.errorf = func(format string, args ...interface{}) {
-		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect %v, actual %v", format, "tester.Errorf called with different message")
-		assertEquals(t, 5, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 6, len(args), "tester.Errorf called with different number of args")
 		assertEquals(t, 1, args[1], "tester.Errorf called with different argument 2")
 		assertEquals(t, 1, args[2], "tester.Errorf called with different argument 3")
 		assertEquals(t, dummyBar+1, args[3], "tester.Errorf called with different argument 4")
@@ -812,8 +1096,8 @@ func TestMocker_ShouldReportTestFailureWhenMockFunctionParameterNilNotEqual(t *t
 
 	// expect
 	tester.errorf = func(format string, args ...interface{}) {
-		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect %v, actual %v", format, "tester.Errorf called with different message")
-		assertEquals(t, 5, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 6, len(args), "tester.Errorf called with different number of args")
 		assertEquals(t, 1, args[1], "tester.Errorf called with different argument 2")
 		assertEquals(t, 1, args[2], "tester.Errorf called with different argument 3")
 		assertEquals(t, nil, args[3], "tester.Errorf called with different argument 4")
@@ -836,8 +1120,8 @@ func TestMocker_ShouldReportTestFailureWhenMockFunctionParameterValueMismatch(t
 
 	// expect
 	tester.errorf = func(format string, args ...interface{}) {
-		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: matchFunc failed on actual %v", format, "tester.Errorf called with different message")
-		assertEquals(t, 4, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: matchFunc failed on actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 5, len(args), "tester.Errorf called with different number of args")
 		assertEquals(t, 1, args[1], "tester.Errorf called with different argument 2")
 		assertEquals(t, 1, args[2], "tester.Errorf called with different argument 3")
 		assertEquals(t, dummyBar, args[3], "tester.Errorf called with different argument 4")
@@ -897,10 +1181,11 @@ func TestMocker_ShouldReportTestFailureWhenMockFunctionVariadicParameterCountMis
 func TestMocker_ShouldReportTestFailureWhenMockFunctionReturnCountMismatch(t *testing.T) {
 	// arrange
 	var foo = func() int { return 0 }
+	var dummyName = "some name"
 	var tester = &tester{t: t}
 
 	// mock
-	var m = NewMocker(tester)
+	var m = NewMocker(tester).(*mocker)
 
 	// expect
 	tester.errorf = func(format string, args ...interface{}) {
@@ -911,11 +1196,53 @@ func TestMocker_ShouldReportTestFailureWhenMockFunctionReturnCountMismatch(t *te
 		assertEquals(t, 0, args[3], "tester.Fatalf called with different argument 4")
 	}
 
-	// SUT
+	// SUT + act
+	var results = m.constructReturns(dummyName, 1, reflect.TypeOf(foo), []interface{}{})
+
+	// assert
+	assertEquals(t, 1, len(results), "constructReturns results count different")
+	assertEquals(t, 0, results[0].Interface(), "constructReturns result 1 different")
+}
+
+func TestMocker_ShouldReportErrorIfReturnCountMismatchWhenCallingTimes(t *testing.T) {
+	// arrange
+	var foo = func() int { return 0 }
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "function or method [%v] cannot be setup with invalid number of returns: expect %v, actual %v", format, "tester.Fatalf called with different message")
+		assertEquals(t, 3, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, 1, args[1], "tester.Fatalf called with different argument 2")
+		assertEquals(t, 0, args[2], "tester.Fatalf called with different argument 3")
+	}
+
+	// SUT + act
 	m.Mock(foo).Expects().Returns().Once()
+}
 
-	// act
-	foo()
+func TestMocker_ShouldReportErrorIfReturnSequenceCountMismatchWhenCallingAtLeast(t *testing.T) {
+	// arrange
+	var foo = func() int { return 0 }
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "function or method [%v] cannot be setup with invalid number of returns in sequence group #%v: expect %v, actual %v", format, "tester.Fatalf called with different message")
+		assertEquals(t, 4, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, 2, args[1], "tester.Fatalf called with different argument 2")
+		assertEquals(t, 1, args[2], "tester.Fatalf called with different argument 3")
+		assertEquals(t, 2, args[3], "tester.Fatalf called with different argument 4")
+	}
+
+	// SUT + act
+	m.Stub(foo).ReturnsSequence([]any{1}, []any{2, 3}).AnyTimes()
 }
 
 func TestMocker_ShouldHandleEntryNotFoundScenarioWhenMakeFunc(t *testing.T) {
@@ -947,6 +1274,60 @@ func TestMocker_ShouldHandleEntryNotFoundScenarioWhenMakeFunc(t *testing.T) {
 	sut.Call([]reflect.Value{})
 }
 
+func TestMocker_ShouldAutoStubEntryNotFoundWithAutoStub(t *testing.T) {
+	// arrange
+	var foo = func(int) (int, error) { return 0, nil }
+	var tester = &tester{t: t}
+	var logfCalled = 0
+
+	// mock
+	var m = NewMocker(tester, WithAutoStub()).(*mocker)
+
+	// expect
+	tester.logf = func(format string, args ...interface{}) {
+		logfCalled++
+		assertEquals(t, "[%v] Auto-stubbed without setup, returning zero values for this and further calls", format, "tester.Logf called with different message")
+		assertEquals(t, 1, len(args), "tester.Logf called with different number of args")
+		assertEquals(t, "foo", args[0], "tester.Logf called with different argument 1")
+	}
+	var funcPtr, _ = m.getFuncPointer(foo)
+	var sut = m.makeFunc("foo", funcPtr, reflect.TypeOf(foo)).Interface().(func(int) (int, error))
+
+	// act
+	var result1, err1 = sut(1)
+	var result2, err2 = sut(2)
+
+	// assert
+	assertEquals(t, 0, result1, "sut call result 1 different")
+	assertEquals(t, nil, err1, "sut call error 1 different")
+	assertEquals(t, 0, result2, "sut call result 2 different")
+	assertEquals(t, nil, err2, "sut call error 2 different")
+	assertEquals(t, 1, logfCalled, "tester.Logf called with different times")
+	assertEquals(t, 2, m.CallCount(foo), "foo call count different")
+	assertEquals(t, 2, m.CallsOf(foo)[1].Args[0], "foo call args different")
+	assertEquals(t, false, m.failed.Load(), "mocker failed different")
+}
+
+func TestMocker_ShouldMockAutoStubbedFunctionWithAutoStub(t *testing.T) {
+	// arrange
+	var foo = func(int) int { return 0 }
+
+	// mock
+	var m = NewMocker(t, WithAutoStub()).(*mocker)
+
+	// expect
+	var funcPtr, _ = m.getFuncPointer(foo)
+	var sut = m.makeFunc("foo", funcPtr, reflect.TypeOf(foo)).Interface().(func(int) int)
+	sut(1)
+	m.Mock(foo).Expects(2).Returns(3).Once()
+
+	// SUT + act
+	var result = foo(2)
+
+	// assert
+	assertEquals(t, 3, result, "foo call result different")
+}
+
 func TestMocker_ShouldHandleEntryCountMismatchScenarioWhenMakeFuncMock(t *testing.T) {
 	// arrange
 	var foo = func() int { return 0 }
@@ -965,8 +1346,8 @@ func TestMocker_ShouldHandleEntryCountMismatchScenarioWhenMakeFuncMock(t *testin
 
 	// expect
 	tester.errorf = func(format string, args ...interface{}) {
-		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v", format, "tester.Fatalf called with different message")
-		assertEquals(t, 3, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v with arguments (%v)", format, "tester.Fatalf called with different message")
+		assertEquals(t, 4, len(args), "tester.Fatalf called with different number of args")
 		assertEquals(t, dummyName, args[0], "tester.Fatalf called with different argument 1")
 		assertEquals(t, 0, args[1], "tester.Fatalf called with different argument 2")
 		assertEquals(t, 1, args[2], "tester.Fatalf called with different argument 3")
@@ -1041,7 +1422,7 @@ func TestMocker_ShouldReportErrorIfAFormerSetupWasIncompleteWhenCallingANewSetup
 	}
 
 	// act
-	m.setup(dummyName, dummyStub, dummyFuncPtr)
+	m.setup(dummyName, dummyStub, dummyFuncPtr, nil)
 }
 
 func TestMocker_ShouldReportErrorIfAFormerSetupWasIncompleteWhenCallingANewSetup2(t *testing.T) {
@@ -1065,7 +1446,7 @@ func TestMocker_ShouldReportErrorIfAFormerSetupWasIncompleteWhenCallingANewSetup
 	}
 
 	// act
-	m.setup(dummyName, dummyStub, dummyFuncPtr)
+	m.setup(dummyName, dummyStub, dummyFuncPtr, nil)
 }
 
 func TestMocker_ShouldReportErrorIfAFormerSetupWasMockButCurrentSetupIsStub(t *testing.T) {
@@ -1091,7 +1472,7 @@ func TestMocker_ShouldReportErrorIfAFormerSetupWasMockButCurrentSetupIsStub(t *t
 	}
 
 	// act
-	m.setup(dummyName, dummyStub, dummyFuncPtr)
+	m.setup(dummyName, dummyStub, dummyFuncPtr, nil)
 }
 
 func TestMocker_ShouldReportErrorIfAFormerSetupWasStubButCurrentSetupIsMock(t *testing.T) {
@@ -1117,7 +1498,7 @@ func TestMocker_ShouldReportErrorIfAFormerSetupWasStubButCurrentSetupIsMock(t *t
 	}
 
 	// act
-	m.setup(dummyName, dummyStub, dummyFuncPtr)
+	m.setup(dummyName, dummyStub, dummyFuncPtr, nil)
 }
 
 func TestMocker_ShouldReportErrorIfAFormerSetupToBeNotCalledButMockAgain(t *testing.T) {
@@ -1146,7 +1527,7 @@ func TestMocker_ShouldReportErrorIfAFormerSetupToBeNotCalledButMockAgain(t *test
 	}
 
 	// act
-	m.setup(dummyName, dummyStub, dummyFuncPtr)
+	m.setup(dummyName, dummyStub, dummyFuncPtr, nil)
 }
 
 func TestMocker_ShouldReportErrorIfAFormerSetupToBeNotCalledButStubAgain(t *testing.T) {
@@ -1175,7 +1556,7 @@ func TestMocker_ShouldReportErrorIfAFormerSetupToBeNotCalledButStubAgain(t *test
 	}
 
 	// act
-	m.setup(dummyName, dummyStub, dummyFuncPtr)
+	m.setup(dummyName, dummyStub, dummyFuncPtr, nil)
 }
 
 func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingExpects(t *testing.T) {
@@ -1216,13 +1597,13 @@ func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingNotCalled(t *testing.
 	m.NotCalled()
 }
 
-func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingReturns(t *testing.T) {
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingNever(t *testing.T) {
 	// arrange
 	var tester = &tester{t: t}
 
 	// expect
 	tester.fatalf = func(format string, args ...interface{}) {
-		assertEquals(t, "Unexpected call to Returns without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, "Unexpected call to Never without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
 		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
 	}
 
@@ -1232,16 +1613,16 @@ func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingReturns(t *testing.T)
 	}
 
 	// act
-	m.Returns()
+	m.Never()
 }
 
-func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingSideEffect(t *testing.T) {
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingReturns(t *testing.T) {
 	// arrange
 	var tester = &tester{t: t}
 
 	// expect
 	tester.fatalf = func(format string, args ...interface{}) {
-		assertEquals(t, "Unexpected call to SideEffect without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, "Unexpected call to Returns without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
 		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
 	}
 
@@ -1251,7 +1632,26 @@ func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingSideEffect(t *testing
 	}
 
 	// act
-	m.SideEffect(nil)
+	m.Returns()
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingSideEffect(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to SideEffect without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.SideEffect(nil)
 }
 
 func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingTimes(t *testing.T) {
@@ -1273,6 +1673,76 @@ func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingTimes(t *testing.T) {
 	m.Times(0)
 }
 
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingTimesIf(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to TimesIf without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.TimesIf(false, 1)
+}
+
+func TestMocker_ShouldExpectCallsWhenConditionIsTrueForTimesIf(t *testing.T) {
+	// arrange
+	var foo = func(int) int { return 0 }
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	m.Mock(foo).Expects(1).Returns(2).TimesIf(true, 1)
+	assertEquals(t, false, m.ExpectationsWereMet() == nil, "expectations before call different")
+
+	// SUT + act
+	var result = foo(1)
+
+	// assert
+	assertEquals(t, 2, result, "result different")
+	m.verifyAll()
+	assertEquals(t, false, m.failed.Load(), "failed different")
+}
+
+func TestMocker_ShouldExpectNoCallWhenConditionIsFalseForTimesIf(t *testing.T) {
+	// arrange
+	var foo = func(int) int { return 0 }
+	var bar = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v with arguments (%v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 0, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, 1, args[2], "tester.Errorf called with different argument 3")
+	}
+	m.Mock(foo).Expects(1).Returns(2).TimesIf(false, 1)
+	m.Stub(bar).Returns().TimesIf(false, 1)
+	assertEquals(t, nil, m.ExpectationsWereMet(), "expectations before call different")
+
+	// SUT + act
+	var result = foo(1)
+	bar()
+
+	// assert
+	assertEquals(t, 0, result, "result different")
+	assertEquals(t, 2, errorfCalled, "tester.Errorf called with different times")
+}
+
 func TestMocker_ShouldReportErrorIfCountIsNegativeWhenCallingTimes(t *testing.T) {
 	// arrange
 	var tester = &tester{t: t}
@@ -1324,3 +1794,5690 @@ func TestMocker_ShouldReportErrorIfCountIsZeroWhenCallingTimes(t *testing.T) {
 	// act
 	m.Times(0)
 }
+
+func TestMocker_ShouldCountMatchedCallsWithCountingMatcher(t *testing.T) {
+	// arrange
+	var foo = func(bar int) int {
+		return 0
+	}
+	var dummyBar = rand.Intn(100)
+	var dummyResult = rand.Intn(100)
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 2, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, dummyBar, args[3], "tester.Errorf called with different argument 4")
+		assertEquals(t, dummyBar+1, args[4], "tester.Errorf called with different argument 5")
+	}
+	var counter = CountingMatcher(dummyBar)
+	m.Mock(foo).Expects(counter).Returns(dummyResult).Times(3)
+
+	// SUT + act
+	foo(dummyBar)
+	foo(dummyBar + 1)
+	foo(dummyBar)
+
+	// assert
+	assertEquals(t, 2, counter.Count(), "counter count different")
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldCountMatchedCallsWithCountingMatcherWrappingMatches(t *testing.T) {
+	// arrange
+	var foo = func(bar int) int {
+		return 0
+	}
+	var dummyResult = rand.Intn(100)
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	var counter = CountingMatcher(Anything())
+	m.Mock(foo).Expects(counter).Returns(dummyResult).Times(3)
+
+	// SUT + act
+	foo(1)
+	foo(2)
+	foo(3)
+
+	// assert
+	assertEquals(t, 3, counter.Count(), "counter count different")
+}
+
+func TestMocker_ShouldStubFunctionReturningTypedNils(t *testing.T) {
+	// arrange
+	type handler func()
+	var foo = func() (map[string]int, []int, chan int, handler, *int) {
+		return map[string]int{}, []int{}, make(chan int), func() {}, new(int)
+	}
+	var dummyMap map[string]int
+	var dummySlice []int
+	var dummyChan chan int
+	var dummyFunc func()
+	var dummyPointer *int
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(foo).Returns(dummyMap, dummySlice, dummyChan, dummyFunc, dummyPointer).Once()
+
+	// SUT + act
+	var result1, result2, result3, result4, result5 = foo()
+
+	// assert
+	assertEquals(t, true, result1 == nil, "foo call result 1 different")
+	assertEquals(t, true, result2 == nil, "foo call result 2 different")
+	assertEquals(t, true, result3 == nil, "foo call result 3 different")
+	assertEquals(t, true, result4 == nil, "foo call result 4 different")
+	assertEquals(t, true, result5 == nil, "foo call result 5 different")
+}
+
+func TestMocker_ShouldStubFunctionReturningTypedNilInsideInterface(t *testing.T) {
+	// arrange
+	var foo = func() (error, any) {
+		return nil, nil
+	}
+	var dummyError *testError
+	var dummyPointer *int
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(foo).Returns(dummyError, dummyPointer).Once()
+
+	// SUT + act
+	var result1, result2 = foo()
+
+	// assert
+	assertEquals(t, true, result1 != nil, "foo call result 1 different")
+	assertEquals(t, dummyError, result1.(*testError), "foo call result 1 different")
+	assertEquals(t, true, result2 != nil, "foo call result 2 different")
+	assertEquals(t, dummyPointer, result2.(*int), "foo call result 2 different")
+}
+
+func TestMocker_ShouldStubFunctionReturningEmptyNonNilValues(t *testing.T) {
+	// arrange
+	var foo = func() (map[string]int, []int) {
+		return nil, nil
+	}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(foo).Returns(map[string]int{}, []int{}).Once()
+
+	// SUT + act
+	var result1, result2 = foo()
+
+	// assert
+	assertEquals(t, false, result1 == nil, "foo call result 1 different")
+	assertEquals(t, false, result2 == nil, "foo call result 2 different")
+}
+
+func TestMocker_ShouldReportTestFailureWithCustomNameWhenMockFunctionParameterValueNotEqual(t *testing.T) {
+	// arrange
+	var foo = func(_ int) {}
+	var tester = &tester{t: t}
+	var dummyName = "Foo"
+	var dummyBar = rand.Intn(100)
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 6, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, dummyName, args[0], "tester.Errorf called with different argument 1")
+	}
+	m.Mock(foo).Named(dummyName).Expects(dummyBar + 1).Returns().Once()
+
+	// SUT + act
+	foo(dummyBar)
+}
+
+func TestMocker_ShouldUseBaseFileNameForFunctionName(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+
+	// SUT
+	var m = NewMocker(tester).(*mocker)
+
+	// act
+	var _, name = m.getFuncPointer(foo)
+
+	// assert
+	assertEquals(t, true, strings.HasPrefix(name, "gomocker_test.go."), "function name different")
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingNamed(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to Named without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.Named("some name")
+}
+
+func TestMocker_ShouldStubFunctionWithReturnsSequenceAnyTimes(t *testing.T) {
+	// arrange
+	var status = func() string {
+		return ""
+	}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(status).ReturnsSequence([]any{"pending"}, []any{"pending"}, []any{"done"}).AnyTimes()
+
+	// SUT + act
+	var result1 = status()
+	var result2 = status()
+	var result3 = status()
+	var result4 = status()
+	var result5 = status()
+
+	// assert
+	assertEquals(t, "pending", result1, "status call result 1 different")
+	assertEquals(t, "pending", result2, "status call result 2 different")
+	assertEquals(t, "done", result3, "status call result 3 different")
+	assertEquals(t, "done", result4, "status call result 4 different")
+	assertEquals(t, "done", result5, "status call result 5 different")
+}
+
+func TestMocker_ShouldMockFunctionAtLeastTimes(t *testing.T) {
+	// arrange
+	var foo = func(bar int) int {
+		return 0
+	}
+	var dummyBar = rand.Intn(100)
+	var dummyResult1 = rand.Intn(100)
+	var dummyResult2 = rand.Intn(100)
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(dummyBar).Returns(dummyResult1).Once()
+	m.Mock(foo).Expects(dummyBar).Returns(dummyResult2).AtLeast(2)
+
+	// SUT + act
+	var result1 = foo(dummyBar)
+	var result2 = foo(dummyBar)
+	var result3 = foo(dummyBar)
+	var result4 = foo(dummyBar)
+
+	// assert
+	assertEquals(t, dummyResult1, result1, "foo call result 1 different")
+	assertEquals(t, dummyResult2, result2, "foo call result 2 different")
+	assertEquals(t, dummyResult2, result3, "foo call result 3 different")
+	assertEquals(t, dummyResult2, result4, "foo call result 4 different")
+}
+
+func TestMocker_ShouldMockFunctionAnyTimesWithoutCalls(t *testing.T) {
+	// arrange
+	var foo = func(bar int) int {
+		return 0
+	}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(Anything()).Returns(0).AnyTimes()
+}
+
+func TestMocker_ShouldReportTestFailureWhenMockFunctionIsCalledLessThanAtLeast(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		assertEquals(t, "[%v] Unepxected number of calls: expect at least %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 4, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 2, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, 1, args[2], "tester.Errorf called with different argument 3")
+	}
+	m.Mock(foo).Expects().Returns().AtLeast(2)
+
+	// SUT + act
+	foo()
+}
+
+func TestMocker_ShouldReportErrorIfAFormerSetupWasUnboundedWhenCallingANewSetup(t *testing.T) {
+	// arrange
+	var dummyName = "some name"
+	var dummyStub = false
+	var dummyFuncPtr = uintptr(rand.Intn(100))
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "A former setup for function or method [%v] was for any number of calls, therefore no more Mock or Stub can be setup for it now.", format, "tester.Fatalf called with different message")
+		assertEquals(t, 1, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, dummyName, args[0], "tester.Fatalf called with different argument 1")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+		entries: map[uintptr]*funcEntry{
+			dummyFuncPtr: {
+				unbounded: true,
+			},
+		},
+	}
+
+	// act
+	m.setup(dummyName, dummyStub, dummyFuncPtr, nil)
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingReturnsSequence(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to ReturnsSequence without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.ReturnsSequence()
+}
+
+func TestMocker_ShouldStubFunctionWithReturnsCycleAnyTimes(t *testing.T) {
+	// arrange
+	var toggle = func() (string, error) {
+		return "", nil
+	}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(toggle).ReturnsCycle([]any{"on", nil}, []any{"off", nil}).AnyTimes()
+
+	// SUT + act
+	var results = []string{}
+	for i := 0; i < 5; i++ {
+		var result, _ = toggle()
+		results = append(results, result)
+	}
+
+	// assert
+	assertEquals(t, "[on off on off on]", fmt.Sprint(results), "toggle call results different")
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingReturnsCycle(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to ReturnsCycle without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.ReturnsCycle()
+}
+
+func TestMocker_ShouldReportErrorIfCycleIsEmptyWhenCallingReturnsCycle(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+	var dummyName = "some name"
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "function or method [%v] cannot be setup with an empty return sequence", format, "tester.Fatalf called with different message")
+		assertEquals(t, 1, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, dummyName, args[0], "tester.Fatalf called with different argument 1")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+		current: &funcEntry{
+			name: dummyName,
+		},
+		temp: &mockEntry{},
+	}
+
+	// act
+	m.ReturnsCycle()
+}
+
+func TestMocker_ShouldReportErrorIfSequenceIsEmptyWhenCallingReturnsSequence(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+	var dummyName = "some name"
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "function or method [%v] cannot be setup with an empty return sequence", format, "tester.Fatalf called with different message")
+		assertEquals(t, 1, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, dummyName, args[0], "tester.Fatalf called with different argument 1")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+		current: &funcEntry{
+			name: dummyName,
+		},
+		temp: &mockEntry{},
+	}
+
+	// act
+	m.ReturnsSequence()
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingAtLeast(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to AtLeast without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.AnyTimes()
+}
+
+func TestMocker_ShouldReportErrorIfCountIsNegativeWhenCallingAtLeast(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+	var dummyName = "some name"
+	var dummyCount = -1 - rand.Intn(100)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "function or method [%v] cannot be mocked for at least negative [%v] times", format, "tester.Fatalf called with different message")
+		assertEquals(t, 2, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, dummyName, args[0], "tester.Fatalf called with different argument 1")
+		assertEquals(t, dummyCount, args[1], "tester.Fatalf called with different argument 2")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+		current: &funcEntry{
+			name: dummyName,
+		},
+		temp: &mockEntry{},
+	}
+
+	// act
+	m.AtLeast(dummyCount)
+}
+
+func TestMocker_ShouldMockFunctionWithGomockStyleChain(t *testing.T) {
+	// arrange
+	var foo = func(bar int, baz error, bam ...string) int {
+		return 0
+	}
+	var dummyBar = rand.Intn(100)
+	var dummyResult = rand.Intn(100)
+	var doCalled = 0
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(dummyBar, nil, "a", "b").Return(dummyResult).Do(func(bar int, baz error, bam ...string) {
+		doCalled++
+		assertEquals(t, dummyBar, bar, "foo call do bar different")
+		assertEquals(t, nil, baz, "foo call do baz different")
+		assertEquals(t, 2, len(bam), "foo call do bam count different")
+	}).Times(2)
+	m.Mock(foo).Expects(Anything(), Anything()).Return(dummyResult).AnyTimes()
+
+	// SUT + act
+	var result1 = foo(dummyBar, nil, "a", "b")
+	var result2 = foo(dummyBar, nil, "a", "b")
+	var result3 = foo(dummyBar+1, nil)
+
+	// assert
+	assertEquals(t, dummyResult, result1, "foo call result 1 different")
+	assertEquals(t, dummyResult, result2, "foo call result 2 different")
+	assertEquals(t, dummyResult, result3, "foo call result 3 different")
+	assertEquals(t, 2, doCalled, "foo call do count different")
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingDo(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to Do without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.Do(func() {})
+}
+
+func TestMocker_ShouldReportErrorIfActionIsNotFunctionWhenCallingDo(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+	var dummyName = "some name"
+	var dummyAction = rand.Intn(100)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "function or method [%v] cannot be setup with a non-function action [%v] using Do method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 2, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, dummyName, args[0], "tester.Fatalf called with different argument 1")
+		assertEquals(t, dummyAction, args[1], "tester.Fatalf called with different argument 2")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+		current: &funcEntry{
+			name: dummyName,
+		},
+		temp: &mockEntry{},
+	}
+
+	// act
+	m.Do(dummyAction)
+}
+
+func TestMocker_ShouldMockFunctionWithReturnsError(t *testing.T) {
+	// arrange
+	var foo = func(bar int) (*int, string, error) {
+		return nil, "", nil
+	}
+	var dummyBar = rand.Intn(100)
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(dummyBar).ReturnsError(dummyError).Once()
+
+	// SUT + act
+	var result1, result2, err = foo(dummyBar)
+
+	// assert
+	assertEquals(t, true, result1 == nil, "foo call result 1 different")
+	assertEquals(t, "", result2, "foo call result 2 different")
+	assertEquals(t, dummyError, err, "foo call error different")
+}
+
+func TestMocker_ShouldStubFunctionReturningOnlyErrorWithReturnsError(t *testing.T) {
+	// arrange
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub((*testErrorer).Do).ReturnsError(dummyError).Once()
+
+	// SUT + act
+	var err = (&testErrorer{}).Do()
+
+	// assert
+	assertEquals(t, dummyError, err, "testErrorer.Do call error different")
+}
+
+type testErrorer struct{}
+
+func (e *testErrorer) Do() error {
+	return nil
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingReturnsError(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to ReturnsError without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.ReturnsError(nil)
+}
+
+func TestMocker_ShouldReportErrorIfLastReturnIsNotErrorWhenCallingReturnsError(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+	var dummyName = "some name"
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "function or method [%v] cannot be setup with ReturnsError as its last return is not of type error", format, "tester.Fatalf called with different message")
+		assertEquals(t, 1, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, dummyName, args[0], "tester.Fatalf called with different argument 1")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+		current: &funcEntry{
+			name:     dummyName,
+			funcType: reflect.TypeOf(func() (error, int) { return nil, 0 }),
+		},
+		temp: &mockEntry{},
+	}
+
+	// act
+	m.ReturnsError(nil)
+}
+
+func TestMocker_ShouldMockFunctionWithReturnsFromChannel(t *testing.T) {
+	// arrange
+	var job = func(id int) (int, error) {
+		return 0, nil
+	}
+	var channel = make(chan []any)
+	var results = make(chan int)
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(job).Expects(Anything()).ReturnsFromChannel(channel).Twice()
+
+	// SUT
+	for i := 0; i < 2; i++ {
+		go func(id int) {
+			var result, _ = job(id)
+			results <- result
+		}(i)
+	}
+
+	// act
+	channel <- []any{1, nil}
+	var result1 = <-results
+	channel <- []any{2, nil}
+	var result2 = <-results
+
+	// assert
+	assertEquals(t, 1, result1, "job call result 1 different")
+	assertEquals(t, 2, result2, "job call result 2 different")
+}
+
+func TestMocker_ShouldCountConcurrentCallsWithoutDataRace(t *testing.T) {
+	// arrange
+	var foo = func(int) int { return 0 }
+	var bar = func(int) int { return 0 }
+	var waitGroup = &sync.WaitGroup{}
+	var total = atomic.Int64{}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(Anything()).Returns(1).Times(50)
+	m.Stub(bar).ReturnsSequence([]any{1}, []any{2}).AnyTimes()
+
+	// SUT
+	for i := 0; i < 50; i++ {
+		waitGroup.Add(1)
+		go func(id int) {
+			defer waitGroup.Done()
+			total.Add(int64(foo(id)))
+			bar(id)
+			m.CallCount(foo)
+		}(i)
+	}
+
+	// act
+	waitGroup.Wait()
+
+	// assert
+	assertEquals(t, int64(50), total.Load(), "total different")
+	assertEquals(t, 50, m.CallCount(foo), "foo call count different")
+	assertEquals(t, 50, len(m.CallsOf(bar)), "bar calls different")
+}
+
+func TestMocker_ShouldRecordConcurrentCallsWithoutDataRace(t *testing.T) {
+	// arrange
+	var foo = func(int) int { return 0 }
+	var bar = func(int) int { return 0 }
+	var baz = func(int) int { return 0 }
+	var waitGroup = &sync.WaitGroup{}
+	var sideEffects = atomic.Int64{}
+	var postSideEffects = atomic.Int64{}
+	var locker = &sync.Mutex{}
+	var messages = []string{}
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester, WithDebugLog())
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		locker.Lock()
+		defer locker.Unlock()
+		messages = append(messages, format)
+	}
+	tester.logf = func(format string, args ...interface{}) {}
+	m.Mock(foo).Expects(Matches(func(value any) bool {
+		return value.(int)%2 == 0
+	})).Returns(1).SideEffect(func(index int, params ...interface{}) {
+		sideEffects.Add(1)
+	}).PostSideEffect(func(index int, returns ...interface{}) {
+		postSideEffects.Add(1)
+	}).Times(50)
+	m.Mock(bar).Unordered().Expects(Anything()).Returns(2).Times(50)
+	m.Stub(baz).Returns(3).AnyTimes()
+
+	// SUT
+	for i := 0; i < 50; i++ {
+		waitGroup.Add(1)
+		go func(id int) {
+			defer waitGroup.Done()
+			foo(id)
+			bar(id)
+			baz(id)
+			m.CallsOf(foo)
+			m.AssertCalledWith(baz, id)
+			m.TotalCalls()
+		}(i)
+	}
+
+	// act
+	waitGroup.Wait()
+
+	// assert
+	assertEquals(t, int64(50), sideEffects.Load(), "side effects different")
+	assertEquals(t, int64(50), postSideEffects.Load(), "post side effects different")
+	assertEquals(t, 25, len(messages), "mismatches different")
+	assertEquals(t, 50, len(m.CallsOf(foo)), "foo calls different")
+	assertEquals(t, 150, m.TotalCalls(), "total calls different")
+}
+
+func TestMocker_ShouldReportTestFailureWhenReturnsChannelIsClosed(t *testing.T) {
+	// arrange
+	var foo = func() int {
+		return 0
+	}
+	var channel = make(chan []any)
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		assertEquals(t, "[%v] Returns channel closed before returns were sent at call #%v", format, "tester.Errorf called with different message")
+		assertEquals(t, 2, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 1, args[1], "tester.Errorf called with different argument 2")
+	}
+	m.Mock(foo).Expects().ReturnsFromChannel(channel).Once()
+	close(channel)
+
+	// SUT + act
+	var result = foo()
+
+	// assert
+	assertEquals(t, 0, result, "foo call result different")
+}
+
+func TestMocker_ShouldReportTestFailureWhenReturnsFromChannelOfInvalidType(t *testing.T) {
+	// arrange
+	var foo = func() (int, error) {
+		return 0, nil
+	}
+	var channel = make(chan []any, 1)
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Invalid type of return #%v at call #%v: expect %v, actual %v", format, "tester.Errorf called with different message")
+		assertEquals(t, 5, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 1, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, 1, args[2], "tester.Errorf called with different argument 3")
+		assertEquals(t, reflect.TypeOf(0), args[3], "tester.Errorf called with different argument 4")
+		assertEquals(t, reflect.TypeOf(""), args[4], "tester.Errorf called with different argument 5")
+	}
+	m.Mock(foo).Expects().ReturnsFromChannel(channel).Once()
+	channel <- []any{"1", nil}
+
+	// SUT + act
+	var result, err = foo()
+
+	// assert
+	assertEquals(t, 0, result, "foo call result different")
+	assertEquals(t, nil, err, "foo call error different")
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportTestFailureWhenConditionalReturnOfInvalidType(t *testing.T) {
+	// arrange
+	var foo = func(int) int {
+		return 0
+	}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Invalid type of return #%v at call #%v: expect %v, actual %v", format, "tester.Errorf called with different message")
+		assertEquals(t, 5, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, reflect.TypeOf(0), args[3], "tester.Errorf called with different argument 4")
+		assertEquals(t, reflect.TypeOf(int64(0)), args[4], "tester.Errorf called with different argument 5")
+	}
+	m.Stub(foo).Returns(1).ConditionalReturn(func(args []any) ([]any, bool) {
+		return []any{int64(2)}, true
+	}).Once()
+
+	// SUT + act
+	var result = foo(1)
+
+	// assert
+	assertEquals(t, 0, result, "foo call result different")
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportTestFailureWhenCallIsBlockedOnReturnsChannelAtVerification(t *testing.T) {
+	// arrange
+	var foo = func() int {
+		return 0
+	}
+	var channel = make(chan []any)
+	var results = make(chan int)
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Calls still blocked waiting for returns from channel at verification: %v", format, "tester.Errorf called with different message")
+		assertEquals(t, 2, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, int64(1), args[1], "tester.Errorf called with different argument 2")
+	}
+	m.Mock(foo).Expects().ReturnsFromChannel(channel).Once()
+	var funcPtr, _ = m.getFuncPointer(foo)
+	var entry = m.entries[funcPtr]
+
+	// SUT
+	go func() {
+		results <- foo()
+	}()
+	for entry.blocked.Load() == 0 {
+		runtime.Gosched()
+	}
+
+	// act
+	m.verifyAll()
+	var result = <-results
+
+	// assert
+	assertEquals(t, 0, result, "foo call result different")
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingReturnsFromChannel(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to ReturnsFromChannel without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.ReturnsFromChannel(nil)
+}
+
+func TestMocker_ShouldMockFunctionExpectsAnyOrder(t *testing.T) {
+	// arrange
+	var foo = func(bar int, baz ...string) int {
+		return 0
+	}
+	var dummyResult = rand.Intn(100)
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).ExpectsAnyOrder(
+		[]any{1, "a"},
+		[]any{2},
+		[]any{3, Anything(), "c"},
+	).Returns(dummyResult).Times(3)
+
+	// SUT + act
+	var result1 = foo(3, "b", "c")
+	var result2 = foo(1, "a")
+	var result3 = foo(2)
+
+	// assert
+	assertEquals(t, dummyResult, result1, "foo call result 1 different")
+	assertEquals(t, dummyResult, result2, "foo call result 2 different")
+	assertEquals(t, dummyResult, result3, "foo call result 3 different")
+}
+
+func TestMocker_ShouldReportTestFailureWhenMockFunctionParametersMismatchInAnyOrder(t *testing.T) {
+	// arrange
+	var foo = func(bar int) {}
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		assertEquals(t, "[%v] Parameter mismatch in any order: missing %v, extra %v", format, "tester.Errorf called with different message")
+		assertEquals(t, 3, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "[[2]]", fmt.Sprint(args[1]), "tester.Errorf called with different argument 2")
+		assertEquals(t, "[[4]]", fmt.Sprint(args[2]), "tester.Errorf called with different argument 3")
+	}
+	m.Mock(foo).ExpectsAnyOrder([]any{1}, []any{2}, []any{3}).Returns().Times(3)
+
+	// SUT + act
+	foo(3)
+	foo(4)
+	foo(1)
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingExpectsAnyOrder(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to ExpectsAnyOrder without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.ExpectsAnyOrder()
+}
+
+func TestMocker_ShouldMockFunctionExpectsDistinct(t *testing.T) {
+	// arrange
+	var foo = func(bar int, baz ...string) int {
+		return 0
+	}
+	var dummyResult = rand.Intn(100)
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).ExpectsDistinct().Returns(dummyResult).Times(3)
+
+	// SUT + act
+	var result1 = foo(1, "a")
+	var result2 = foo(1, "b")
+	var result3 = foo(2, "a")
+
+	// assert
+	assertEquals(t, dummyResult, result1, "foo call result 1 different")
+	assertEquals(t, dummyResult, result2, "foo call result 2 different")
+	assertEquals(t, dummyResult, result3, "foo call result 3 different")
+}
+
+func TestMocker_ShouldReportTestFailureWhenMockFunctionCalledWithDuplicateArguments(t *testing.T) {
+	// arrange
+	var foo = func(bar int, baz string) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Duplicate arguments at call #%v: (%v) same as call #%v", format, "tester.Errorf called with different message")
+		assertEquals(t, 4, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 3, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, "1, a", args[2], "tester.Errorf called with different argument 3")
+		assertEquals(t, 1, args[3], "tester.Errorf called with different argument 4")
+	}
+	m.Mock(foo).ExpectsDistinct().Returns().Times(3)
+
+	// SUT + act
+	foo(1, "a")
+	foo(2, "a")
+	foo(1, "a")
+	m.Close()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingExpectsDistinct(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to ExpectsDistinct without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.ExpectsDistinct()
+}
+
+func TestMocker_ShouldStubFunctionWithReturnsCopy(t *testing.T) {
+	// arrange
+	type response struct {
+		Items  []int
+		Lookup map[string]*int
+	}
+	var foo = func() ([]int, *response) {
+		return nil, nil
+	}
+	var dummyValue = rand.Intn(100)
+	var dummySlice = []int{1, 2, 3}
+	var dummyResponse = &response{
+		Items:  []int{4, 5},
+		Lookup: map[string]*int{"a": &dummyValue},
+	}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(foo).ReturnsCopy(dummySlice, dummyResponse).Twice()
+
+	// SUT + act
+	var result1, response1 = foo()
+	result1[0] = 100
+	response1.Items[0] = 100
+	*response1.Lookup["a"] = 100
+	var result2, response2 = foo()
+
+	// assert
+	assertEquals(t, 1, result2[0], "foo call result 2 different")
+	assertEquals(t, 4, response2.Items[0], "foo call response 2 items different")
+	assertEquals(t, dummyValue, *response2.Lookup["a"], "foo call response 2 lookup different")
+	assertEquals(t, 1, dummySlice[0], "dummy slice different")
+	assertEquals(t, false, response1 == response2, "foo call responses not copied")
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingReturnsCopy(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to ReturnsCopy without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.ReturnsCopy()
+}
+
+type testCloneable struct {
+	value  int
+	clones int
+}
+
+func (c *testCloneable) Clone() *testCloneable {
+	c.clones++
+	return &testCloneable{value: c.value}
+}
+
+func TestMocker_ShouldStubFunctionWithCopiedReturnsOption(t *testing.T) {
+	// arrange
+	var foo = func() (map[string]int, *testCloneable) {
+		return nil, nil
+	}
+	var dummyMap = map[string]int{"a": 1}
+	var dummyCloneable = &testCloneable{value: rand.Intn(100)}
+
+	// mock
+	var m = NewMocker(t, WithCopiedReturns())
+
+	// expect
+	m.Stub(foo).Returns(dummyMap, dummyCloneable).Twice()
+
+	// SUT + act
+	var result1, cloneable1 = foo()
+	result1["a"] = 100
+	var result2, cloneable2 = foo()
+
+	// assert
+	assertEquals(t, 1, result2["a"], "foo call result 2 different")
+	assertEquals(t, 1, dummyMap["a"], "dummy map different")
+	assertEquals(t, dummyCloneable.value, cloneable1.value, "foo call cloneable 1 different")
+	assertEquals(t, dummyCloneable.value, cloneable2.value, "foo call cloneable 2 different")
+	assertEquals(t, 2, dummyCloneable.clones, "dummy cloneable clone count different")
+}
+
+func TestMocker_ShouldMockFunctionWithElementsMatch(t *testing.T) {
+	// arrange
+	var foo = func(bar []string) int {
+		return 0
+	}
+	var dummyResult = rand.Intn(100)
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(ElementsMatch([]string{"a", "b", "b", "c"})).Returns(dummyResult).Once()
+
+	// SUT + act
+	var result = foo([]string{"b", "c", "a", "b"})
+
+	// assert
+	assertEquals(t, dummyResult, result, "foo call result different")
+}
+
+func TestMocker_ShouldReportTestFailureWhenMockFunctionElementsMismatch(t *testing.T) {
+	// arrange
+	var foo = func(bar []string) {}
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: elements mismatch, missing %v, extra %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 6, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "[b]", fmt.Sprint(args[3]), "tester.Errorf called with different argument 4")
+		assertEquals(t, "[]", fmt.Sprint(args[4]), "tester.Errorf called with different argument 5")
+	}
+	m.Mock(foo).Expects(ElementsMatch([]string{"a", "b", "b"})).Returns().Once()
+
+	// SUT + act
+	foo([]string{"b", "a"})
+}
+
+func TestMocker_ShouldReportTestFailureWhenMockFunctionElementsMatchNonList(t *testing.T) {
+	// arrange
+	var foo = func(bar int) {}
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect elements of %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 6, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 1, args[4], "tester.Errorf called with different argument 5")
+	}
+	m.Mock(foo).Expects(ElementsMatch([]int{1})).Returns().Once()
+
+	// SUT + act
+	foo(1)
+}
+
+func TestMocker_ShouldMockFunctionsInOrder(t *testing.T) {
+	// arrange
+	var begin = func() {}
+	var write = func(int) {}
+	var commit = func() {}
+	var log = func() {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.InOrder(
+		m.Mock(begin).Expects().Returns().Once(),
+		m.Mock(write).Expects(Anything()).Returns().Twice(),
+		m.Mock(commit).Expects().Returns().Once(),
+	)
+	m.Stub(log).Returns().AnyTimes()
+
+	// SUT + act
+	log()
+	begin()
+	write(1)
+	log()
+	write(2)
+	commit()
+	log()
+}
+
+func TestMocker_ShouldReportTestFailureWhenMockFunctionsCalledOutOfOrder(t *testing.T) {
+	// arrange
+	var begin = func() {}
+	var write = func() {}
+	var commit = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Unexpected call order at call #%v: expect [%v] to be called before, actual order %v", format, "tester.Errorf called with different message")
+		assertEquals(t, 4, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 1, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, "write", args[2], "tester.Errorf called with different argument 3")
+		assertEquals(t, "[begin commit]", fmt.Sprint(args[3]), "tester.Errorf called with different argument 4")
+	}
+	var beginSetup = m.Mock(begin).Named("begin").Expects().Returns().Once()
+	var writeSetup = m.Mock(write).Named("write").Expects().Returns().Once()
+	var commitSetup = m.Mock(commit).Named("commit").Expects().Returns().Once()
+	m.InOrder(beginSetup, writeSetup, commitSetup)
+
+	// SUT + act
+	begin()
+	commit()
+	write()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportErrorIfSetupIsInvalidWhenCallingInOrder(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected setup #%v passed to InOrder, only the results of Once/Twice/Times/AtLeast/AnyTimes are supported", format, "tester.Fatalf called with different message")
+		assertEquals(t, 1, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, 1, args[0], "tester.Fatalf called with different argument 1")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.InOrder(m)
+}
+
+func TestMocker_ShouldMockFunctionsInSequences(t *testing.T) {
+	// arrange
+	var open = func() {}
+	var read = func() {}
+	var begin = func() {}
+	var write = func() {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	var reads = m.Sequence("read pipeline")
+	var writes = m.Sequence("write pipeline")
+	m.Mock(open).Expects().Returns().Once().In(reads)
+	m.Mock(begin).Expects().Returns().Once().In(writes)
+	m.Mock(read).Expects().Returns().Twice().In(reads)
+	m.Mock(write).Expects().Returns().Once().In(writes)
+
+	// SUT + act
+	begin()
+	open()
+	write()
+	read()
+	read()
+}
+
+func TestMocker_ShouldReportTestFailureWhenMockFunctionsCalledOutOfSequence(t *testing.T) {
+	// arrange
+	var open = func() {}
+	var read = func() {}
+	var write = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Unexpected call order in sequence [%v] at call #%v: expect position #%v [%v] to be called before, actual position #%v", format, "tester.Errorf called with different message")
+		assertEquals(t, 6, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "read", args[0], "tester.Errorf called with different argument 1")
+		assertEquals(t, "read pipeline", args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, 1, args[2], "tester.Errorf called with different argument 3")
+		assertEquals(t, 1, args[3], "tester.Errorf called with different argument 4")
+		assertEquals(t, "open", args[4], "tester.Errorf called with different argument 5")
+		assertEquals(t, 2, args[5], "tester.Errorf called with different argument 6")
+	}
+	var reads = m.Sequence("read pipeline")
+	var writes = m.Sequence("write pipeline")
+	m.Mock(open).Named("open").Expects().Returns().Once().In(reads)
+	m.Mock(read).Named("read").Expects().Returns().Once().In(reads)
+	m.Mock(write).Named("write").Expects().Returns().Once().In(writes)
+
+	// SUT + act
+	write()
+	read()
+	open()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportErrorIfNoCompletedSetupWhenCallingIn(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to In without completing a setup using Once/Twice/Times/AtLeast/AnyTimes", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.incomplete().In(m.Sequence("dummy"))
+}
+
+func TestMocker_ShouldReportErrorIfSequenceIsNilWhenCallingIn(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected nil sequence passed to In, only the results of Sequence method are supported", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT + act
+	m.Mock(foo).Expects().Returns().AnyTimes().In(nil)
+}
+
+var testFuncVar = func(bar int) int {
+	return bar * 2
+}
+
+func TestMocker_ShouldMockFunctionVariableAndRestore(t *testing.T) {
+	// arrange
+	var original = reflect.ValueOf(testFuncVar).Pointer()
+	var dummyBar = rand.Intn(100)
+	var dummyResult = rand.Intn(100)
+
+	t.Run("mock", func(t *testing.T) {
+		// mock
+		var m = NewMocker(t)
+
+		// expect
+		m.MockFuncVar(&testFuncVar).Expects(dummyBar).Returns(dummyResult).Once()
+		m.MockFuncVar(&testFuncVar).Expects(dummyBar + 1).Returns(dummyResult + 1).Once()
+
+		// SUT + act
+		var result1 = testFuncVar(dummyBar)
+		var result2 = testFuncVar(dummyBar + 1)
+
+		// assert
+		assertEquals(t, dummyResult, result1, "testFuncVar call result 1 different")
+		assertEquals(t, dummyResult+1, result2, "testFuncVar call result 2 different")
+	})
+
+	// assert
+	assertEquals(t, original, reflect.ValueOf(testFuncVar).Pointer(), "testFuncVar not restored")
+	assertEquals(t, dummyBar*2, testFuncVar(dummyBar), "testFuncVar call result different")
+}
+
+func TestMocker_ShouldReportErrorIfCallCountMismatchWhenCallingMockFuncVar(t *testing.T) {
+	// arrange
+	var fetchUser func(id string) (string, error)
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 4, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "variable of func(string) (string, error)", args[0], "tester.Errorf called with different argument 1")
+		assertEquals(t, 2, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, 1, args[2], "tester.Errorf called with different argument 3")
+	}
+	m.MockFuncVar(&fetchUser).Expects("foo").Returns("bar", nil).Twice()
+
+	// SUT
+	var result, err = fetchUser("foo")
+
+	// act
+	m.(*mocker).verifyAll()
+
+	// assert
+	assertEquals(t, "bar", result, "fetchUser call result different")
+	assertEquals(t, nil, err, "fetchUser call error different")
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+	assertEquals(t, true, fetchUser == nil, "fetchUser not restored")
+}
+
+func TestMocker_ShouldReportErrorIfTargetIsInvalidWhenCallingMockFuncVar(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+	var dummyTarget = rand.Intn(100)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected target [%v] passed to MockFuncVar, only a pointer to a function variable is supported", format, "tester.Fatalf called with different message")
+		assertEquals(t, 1, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, &dummyTarget, args[0], "tester.Fatalf called with different argument 1")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+		locker: &sync.Mutex{},
+	}
+
+	// act
+	m.MockFuncVar(&dummyTarget)
+}
+
+func TestMocker_ShouldStubFunctionWithReturnsChannel(t *testing.T) {
+	// arrange
+	var subscribe = func(topic string) (<-chan int, error) {
+		return nil, nil
+	}
+	var dummyValue = rand.Intn(100)
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	var channel, counter = ReturnsChannel[int](m.Stub(subscribe), 1)
+	counter.Once()
+
+	// SUT
+	var events, err = subscribe("some topic")
+
+	// act
+	channel <- dummyValue
+	var result = <-events
+
+	// assert
+	assertEquals(t, nil, err, "subscribe call error different")
+	assertEquals(t, dummyValue, result, "subscribe channel value different")
+}
+
+func TestMocker_ShouldStubFunctionWithReturnsChannelFromTypedReturner(t *testing.T) {
+	// arrange
+	var subscribe = func(topic string) (<-chan int, error) {
+		return nil, nil
+	}
+	var dummyValue = rand.Intn(100)
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	var channel, counter = ReturnsChannel[int](Mock1x2(m, subscribe).Expects("some topic"), 1)
+	counter.Once()
+
+	// SUT
+	var events, err = subscribe("some topic")
+
+	// act
+	channel <- dummyValue
+	var result = <-events
+
+	// assert
+	assertEquals(t, nil, err, "subscribe call error different")
+	assertEquals(t, dummyValue, result, "subscribe channel value different")
+}
+
+func TestMocker_ShouldPanicIfReturnerIsNotFromMockerWhenCallingReturnsChannel(t *testing.T) {
+	// arrange
+	var recovered interface{}
+
+	// act
+	func() {
+		defer func() {
+			recovered = recover()
+		}()
+		ReturnsChannel[int](&TypedReturner1[int]{}, 0)
+	}()
+
+	// assert
+	assertEquals(t, "Unexpected returner [*gomocker.TypedReturner1[int]] passed to ReturnsChannel, only the Returner from a mock or stub of a mocker is supported", recovered, "ReturnsChannel panic different")
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingReturnsChannel(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to ReturnsChannel without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	ReturnsChannel[int](m, 0)
+}
+
+func TestMocker_ShouldReportErrorIfNoReturnAcceptsChannelWhenCallingReturnsChannel(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+	var dummyName = "some name"
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "function or method [%v] cannot be setup with ReturnsChannel as none of its returns accepts [%v]", format, "tester.Fatalf called with different message")
+		assertEquals(t, 2, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, dummyName, args[0], "tester.Fatalf called with different argument 1")
+		assertEquals(t, reflect.TypeOf(make(chan int)), args[1], "tester.Fatalf called with different argument 2")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+		current: &funcEntry{
+			name:     dummyName,
+			funcType: reflect.TypeOf(func() (int, chan string) { return 0, nil }),
+		},
+		temp: &mockEntry{},
+	}
+
+	// act
+	ReturnsChannel[int](m, 0)
+}
+
+func TestMocker_ShouldMockFunctionsAfterPrerequisites(t *testing.T) {
+	// arrange
+	var open = func() {}
+	var write = func() {}
+	var close = func() {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	var openSetup = m.Mock(open).Expects().Returns().Once()
+	var writeSetup = m.Mock(write).Expects().Returns().Once()
+	m.Mock(close).Expects().Returns().Once().After(openSetup, writeSetup)
+
+	// SUT + act
+	write()
+	open()
+	close()
+}
+
+func TestMocker_ShouldReportTestFailureWhenMockFunctionCalledBeforePrerequisites(t *testing.T) {
+	// arrange
+	var write = func() {}
+	var flush = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Unexpected call order at call #%v: expect setup #%v [%v] to be called before setup #%v", format, "tester.Errorf called with different message")
+		assertEquals(t, 5, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "flush", args[0], "tester.Errorf called with different argument 1")
+		assertEquals(t, 1, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, 1, args[2], "tester.Errorf called with different argument 3")
+		assertEquals(t, "write", args[3], "tester.Errorf called with different argument 4")
+		assertEquals(t, 2, args[4], "tester.Errorf called with different argument 5")
+	}
+	var writeSetup = m.Mock(write).Named("write").Expects().Returns().Once()
+	m.Mock(flush).Named("flush").Expects().Returns().Once().After(writeSetup)
+
+	// SUT + act
+	flush()
+	write()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportErrorIfNoCompletedSetupWhenCallingAfter(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to After without completing a setup using Once/Twice/Times/AtLeast/AnyTimes", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.incomplete().After()
+}
+
+func TestMocker_ShouldReportErrorIfSetupIsInvalidWhenCallingAfter(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected setup #%v passed to After, only the results of Once/Twice/Times/AtLeast/AnyTimes are supported", format, "tester.Fatalf called with different message")
+		assertEquals(t, 1, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, 1, args[0], "tester.Fatalf called with different argument 1")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+	var e = &expectation{
+		mocker: m,
+		mock:   &mockEntry{},
+	}
+
+	// act
+	e.After(m)
+}
+
+func TestMocker_ShouldMockFunctionsAfterRequiredCalls(t *testing.T) {
+	// arrange
+	var open = func() {}
+	var write = func() {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	var openSetup = m.Mock(open).Expects().Returns().Twice()
+	m.Mock(write).Expects().Returns().Twice().Requires(openSetup)
+
+	// SUT + act
+	open()
+	write()
+	open()
+	write()
+}
+
+func TestMocker_ShouldReportTestFailureWhenMockFunctionCalledBeforeRequiredCalls(t *testing.T) {
+	// arrange
+	var open = func() {}
+	var write = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Unexpected call order at call #%v: expect [%v] to be called at least once before", format, "tester.Errorf called with different message")
+		assertEquals(t, 3, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "write", args[0], "tester.Errorf called with different argument 1")
+		assertEquals(t, 1, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, "open", args[2], "tester.Errorf called with different argument 3")
+	}
+	var openSetup = m.Mock(open).Named("open").Expects().Returns().Once()
+	m.Mock(write).Named("write").Expects().Returns().Once().Requires(openSetup)
+
+	// SUT + act
+	write()
+	open()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportErrorIfNoCompletedSetupWhenCallingRequires(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to Requires without completing a setup using Once/Twice/Times/AtLeast/AnyTimes", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.incomplete().Requires()
+}
+
+func TestMocker_ShouldReportErrorIfSetupIsInvalidWhenCallingRequires(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected setup #%v passed to Requires, only the results of Once/Twice/Times/AtLeast/AnyTimes are supported", format, "tester.Fatalf called with different message")
+		assertEquals(t, 1, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, 1, args[0], "tester.Fatalf called with different argument 1")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+	var e = &expectation{
+		mocker: m,
+		mock:   &mockEntry{},
+	}
+
+	// act
+	e.Requires(m)
+}
+
+func TestMocker_ShouldMockVariadicFunctionWithExpandedParameters(t *testing.T) {
+	// arrange
+	var foo = func(int, ...string) int {
+		return 0
+	}
+	var dummyBar = rand.Intn(100)
+	var dummyResult = rand.Intn(100)
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(dummyBar, "a", Anything()).Returns(dummyResult).Once()
+
+	// SUT + act
+	var result = foo(dummyBar, "a", "b")
+
+	// assert
+	assertEquals(t, dummyResult, result, "foo call result different")
+}
+
+func TestMocker_ShouldMockVariadicFunctionWithParametersAsSlice(t *testing.T) {
+	// arrange
+	var foo = func(int, ...string) int {
+		return 0
+	}
+	var dummyBar = rand.Intn(100)
+	var dummyResult1 = rand.Intn(100)
+	var dummyResult2 = rand.Intn(100)
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).VariadicAsSlice().Expects(dummyBar, []string{"a", "b"}).Returns(dummyResult1).Once()
+	m.Mock(foo).Expects(dummyBar, ElementsMatch([]string{"c", "d"})).Returns(dummyResult2).Once()
+
+	// SUT + act
+	var result1 = foo(dummyBar, "a", "b")
+	var result2 = foo(dummyBar, "d", "c")
+
+	// assert
+	assertEquals(t, dummyResult1, result1, "foo call result 1 different")
+	assertEquals(t, dummyResult2, result2, "foo call result 2 different")
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingVariadicAsSlice(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to VariadicAsSlice without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.VariadicAsSlice()
+}
+
+func TestMocker_ShouldMockFunctionsInStrictOrder(t *testing.T) {
+	// arrange
+	var begin = func() {}
+	var write = func(int) {}
+	var log = func() {}
+
+	// mock
+	var m = NewMocker(t, WithStrictOrder())
+
+	// expect
+	m.Mock(begin).Expects().Returns().Once()
+	m.Mock(write).Expects(1).Returns().Once()
+	m.Stub(log).Returns().AnyTimes()
+	m.Mock(write).Expects(2).Returns().Once()
+	m.Mock(begin).Expects().Returns().Once()
+
+	// SUT + act
+	log()
+	begin()
+	write(1)
+	write(2)
+	log()
+	begin()
+}
+
+func TestMocker_ShouldReportTestFailureWhenMockFunctionsCalledOutOfStrictOrder(t *testing.T) {
+	// arrange
+	var begin = func() {}
+	var write = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester, WithStrictOrder())
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Unexpected call order at call #%v: expect next call to [%v] declared as setup #%v, actual setup #%v", format, "tester.Errorf called with different message")
+		assertEquals(t, 5, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "write", args[0], "tester.Errorf called with different argument 1")
+		assertEquals(t, 1, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, "begin", args[2], "tester.Errorf called with different argument 3")
+		assertEquals(t, 1, args[3], "tester.Errorf called with different argument 4")
+		assertEquals(t, 2, args[4], "tester.Errorf called with different argument 5")
+	}
+	m.Mock(begin).Named("begin").Expects().Returns().Once()
+	m.Mock(write).Named("write").Expects().Returns().Once()
+
+	// SUT + act
+	write()
+	begin()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldMockFunctionUnordered(t *testing.T) {
+	// arrange
+	var fetch = func(id string) (string, error) {
+		return "", nil
+	}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(fetch).Unordered().Expects("a").Returns("user a", nil).Once()
+	m.Mock(fetch).Expects("b").Returns("user b", nil).Once()
+	m.Mock(fetch).Expects("c").Returns("user c", nil).Once()
+
+	// SUT + act
+	var result1, _ = fetch("c")
+	var result2, _ = fetch("a")
+	var result3, _ = fetch("b")
+
+	// assert
+	assertEquals(t, "user c", result1, "fetch call result 1 different")
+	assertEquals(t, "user a", result2, "fetch call result 2 different")
+	assertEquals(t, "user b", result3, "fetch call result 3 different")
+}
+
+func TestMocker_ShouldMockFunctionUnorderedWithAtLeast(t *testing.T) {
+	// arrange
+	var foo = func(bar int) int {
+		return 0
+	}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Unordered().Expects(1).Returns(10).Once()
+	m.Mock(foo).Expects(2).Returns(20).AtLeast(1)
+
+	// SUT + act
+	var result1 = foo(2)
+	var result2 = foo(2)
+	var result3 = foo(1)
+
+	// assert
+	assertEquals(t, 20, result1, "foo call result 1 different")
+	assertEquals(t, 20, result2, "foo call result 2 different")
+	assertEquals(t, 10, result3, "foo call result 3 different")
+}
+
+func TestMocker_ShouldReportTestFailureWhenUnorderedCallMatchesNoRemainingSetup(t *testing.T) {
+	// arrange
+	var foo = func(bar int) int {
+		return 0
+	}
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		assertEquals(t, "[%v] Parameter mismatch at call #%v: actual %v, matching none of the remaining setups %v", format, "tester.Errorf called with different message")
+		assertEquals(t, 4, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 2, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, "[1]", fmt.Sprint(args[2]), "tester.Errorf called with different argument 3")
+		assertEquals(t, "[[2]]", fmt.Sprint(args[3]), "tester.Errorf called with different argument 4")
+	}
+	m.Mock(foo).Unordered().Expects(1).Returns(10).Once()
+	m.Mock(foo).Expects(2).Returns(20).Once()
+
+	// SUT + act
+	var result1 = foo(1)
+	var result2 = foo(1)
+
+	// assert
+	assertEquals(t, 10, result1, "foo call result 1 different")
+	assertEquals(t, 0, result2, "foo call result 2 different")
+}
+
+func TestMocker_ShouldRenderValuesWithFormatterInFailures(t *testing.T) {
+	// arrange
+	var foo = func(string) {}
+	var token = strings.Repeat("x", 100)
+	var tester = &tester{t: t}
+	var messages = []string{}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		messages = append(messages, strings.Split(fmt.Sprintf(format, args...), " (declared at ")[0])
+	}
+	m.Mock(foo).Named("foo").Formatted(func(value any) string {
+		var text = fmt.Sprint(value)
+		if len(text) > 4 {
+			return text[:4] + "..."
+		}
+		return text
+	}).Expects("abc").Returns().Once()
+
+	// SUT + act
+	foo(token)
+	foo(token)
+
+	// assert
+	assertEquals(t, 2, len(messages), "tester.Errorf called with different times")
+	assertEquals(t, "[foo] Parameter mismatch at call #1 parameter #1: expect abc, actual xxxx...", messages[0], "first message different")
+	assertEquals(t, "[foo] Unepxected number of calls: expect 1, actual 2 with arguments (xxxx...)", messages[1], "second message different")
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingFormatted(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to Formatted without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.Formatted(nil)
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingUnordered(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to Unordered without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.Unordered()
+}
+
+func TestMocker_ShouldReportErrorIfSetupIsStubWhenCallingExpects(t *testing.T) {
+	// arrange
+	var foo = func(bar int) int {
+		return 0
+	}
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "function or method [%v] is setup as a Stub, which does not verify parameters. Try using Mock method instead to setup parameter expectations.", format, "tester.Fatalf called with different message")
+		assertEquals(t, 1, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT + act
+	m.Stub(foo).(*returner).Expects(rand.Intn(100))
+}
+
+func TestMocker_ShouldReportErrorIfParameterTypeMismatchWhenCallingTimes(t *testing.T) {
+	// arrange
+	var foo = func(int, ...string) {}
+	var tester = &tester{t: t}
+	var fatalfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		fatalfCalled++
+		assertEquals(t, "function or method [%v] cannot be setup with invalid type of parameter #%v: expect %v, actual %v", format, "tester.Fatalf called with different message")
+		assertEquals(t, 4, len(args), "tester.Fatalf called with different number of args")
+		switch fatalfCalled {
+		case 1:
+			assertEquals(t, 1, args[1], "tester.Fatalf called with different argument 2")
+			assertEquals(t, reflect.TypeFor[int](), args[2], "tester.Fatalf called with different argument 3")
+			assertEquals(t, reflect.TypeFor[string](), args[3], "tester.Fatalf called with different argument 4")
+		case 2:
+			assertEquals(t, 3, args[1], "tester.Fatalf called with different argument 2")
+			assertEquals(t, reflect.TypeFor[string](), args[2], "tester.Fatalf called with different argument 3")
+			assertEquals(t, reflect.TypeFor[int](), args[3], "tester.Fatalf called with different argument 4")
+		}
+	}
+
+	// SUT + act
+	m.Mock(foo).Expects("5").Returns().Once()
+	var n = NewMocker(tester)
+	n.Mock(foo).Expects(5, "a", 6).Returns().Once()
+
+	// assert
+	assertEquals(t, 2, fatalfCalled, "tester.Fatalf called with different times")
+}
+
+func TestMocker_ShouldAcceptAssignableParameterTypesWhenCallingTimes(t *testing.T) {
+	// arrange
+	var foo = func(error, any, []int, ...string) {}
+	var bar = func(error, any, []int, ...string) { _ = 0 }
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(dummyError, 5, nil, Anything(), "b").Returns().Once()
+	m.Mock(bar).VariadicAsSlice().Expects(dummyError, "5", []int{1}, []string{"a"}).Returns().Once()
+
+	// SUT + act
+	foo(dummyError, 5, nil, "a", "b")
+	bar(dummyError, "5", []int{1}, "a")
+}
+
+func TestMocker_ShouldReportErrorIfMatcherTypeMismatchWhenCallingTimes(t *testing.T) {
+	// arrange
+	var foo = func(int) {}
+	var tester = &tester{t: t}
+	var fatalfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		fatalfCalled++
+		assertEquals(t, "function or method [%v] cannot be setup with matcher of type %v for parameter #%v of type %v", format, "tester.Fatalf called with different message")
+		assertEquals(t, 4, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, reflect.TypeFor[string](), args[1], "tester.Fatalf called with different argument 2")
+		assertEquals(t, 1, args[2], "tester.Fatalf called with different argument 3")
+		assertEquals(t, reflect.TypeFor[int](), args[3], "tester.Fatalf called with different argument 4")
+	}
+
+	// SUT + act
+	m.Mock(foo).Expects(MatchesAt(func(index int, value string) bool {
+		return value != ""
+	})).Returns().Once()
+
+	// assert
+	assertEquals(t, 1, fatalfCalled, "tester.Fatalf called with different times")
+}
+
+func TestMocker_ShouldAcceptAssignableMatcherTypesWhenCallingTimes(t *testing.T) {
+	// arrange
+	var foo = func(any, io.Reader, io.Reader, int) {}
+	var buffer = &bytes.Buffer{}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(
+		MatchesAt(func(index int, value int) bool { return value == 1 }),
+		MatchesAt(func(index int, value *bytes.Buffer) bool { return value == buffer }),
+		MatchesAt(func(index int, value io.ReadWriter) bool { return value == buffer }),
+		MatchesAt(func(index int, value any) bool { return value == 4 }),
+	).Returns().Once()
+
+	// SUT + act
+	foo(1, buffer, buffer, 4)
+}
+
+func TestMocker_ShouldReportTestFailureWhenMatchFuncFailsTypeAssertion(t *testing.T) {
+	// arrange
+	var foo = func(any) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: matchFunc failed on actual %v of type %v: %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 7, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 5, args[3], "tester.Errorf called with different argument 4")
+		assertEquals(t, "int", fmt.Sprint(args[4]), "tester.Errorf called with different argument 5")
+		assertEquals(t, "interface conversion: interface {} is int, not string", fmt.Sprint(args[5]), "tester.Errorf called with different argument 6")
+	}
+	m.Mock(foo).Expects(Matches(func(value any) bool {
+		return value.(string) != ""
+	})).Returns().Once()
+
+	// SUT + act
+	foo(5)
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportErrorIfReturnTypeMismatchWhenCallingTimes(t *testing.T) {
+	// arrange
+	var foo = func() (int, error) { return 0, nil }
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "function or method [%v] cannot be setup with invalid type of return #%v: expect %v, actual %v", format, "tester.Fatalf called with different message")
+		assertEquals(t, 4, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, 1, args[1], "tester.Fatalf called with different argument 2")
+		assertEquals(t, reflect.TypeFor[int](), args[2], "tester.Fatalf called with different argument 3")
+		assertEquals(t, reflect.TypeFor[string](), args[3], "tester.Fatalf called with different argument 4")
+	}
+
+	// SUT + act
+	m.Stub(foo).Returns("5", nil).Once()
+}
+
+func TestMocker_ShouldReportErrorIfReturnSequenceTypeMismatchWhenCallingTimes(t *testing.T) {
+	// arrange
+	var foo = func() (int, error) { return 0, nil }
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "function or method [%v] cannot be setup with invalid type of return #%v in sequence group #%v: expect %v, actual %v", format, "tester.Fatalf called with different message")
+		assertEquals(t, 5, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, 2, args[1], "tester.Fatalf called with different argument 2")
+		assertEquals(t, 2, args[2], "tester.Fatalf called with different argument 3")
+		assertEquals(t, reflect.TypeFor[error](), args[3], "tester.Fatalf called with different argument 4")
+		assertEquals(t, reflect.TypeFor[string](), args[4], "tester.Fatalf called with different argument 5")
+	}
+
+	// SUT + act
+	m.Stub(foo).ReturnsSequence([]any{1, nil}, []any{2, "error"}).Once()
+}
+
+type testPatcher struct {
+	*gomonkey.Patches
+}
+
+func (p *testPatcher) ApplyCore(target, double reflect.Value) *gomonkey.Patches {
+	return p.Patches
+}
+
+func TestMocker_ShouldReportErrorIfBoundMethodValueWhenCallingMock(t *testing.T) {
+	// arrange
+	var resource = &testResource{}
+	var tester = &tester{t: t}
+	var fatalfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		fatalfCalled++
+		assertEquals(t, "The function or method [%v] is a bound method value, which cannot be mocked as calls to the method never go through it."+
+			" Try using the method expression instead, e.g. (*foo).Foo rather than obj.Foo, or MockOn for a particular instance.", format, "tester.Fatalf called with different message")
+		assertEquals(t, 1, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, true, strings.HasSuffix(args[0].(string), "(*testResource).Acquire-fm"), "tester.Fatalf called with different argument 1")
+	}
+
+	// SUT + act
+	m.Mock(resource.Acquire)
+	m.Stub(resource.Acquire)
+	m.MockOn(resource, resource.Acquire)
+
+	// assert
+	assertEquals(t, 3, fatalfCalled, "tester.Fatalf called with different times")
+	assertEquals(t, 9, resource.Acquire(9), "Acquire call result different")
+}
+
+func testLookup[T any](value T) T {
+	return value
+}
+
+func TestMocker_ShouldReportErrorIfGenericInstantiationWhenCallingMock(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+	var fatalfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		fatalfCalled++
+		assertEquals(t, "The function or method [%v] of type %v is an instantiation of a generic function or method, which cannot be mocked"+
+			" as its direct calls run the code shared by all instantiations of the same shape rather than the value passed in."+
+			" Try assigning the instantiation to a function variable used by the code, e.g. `var lookup = Lookup[string]`, and MockFuncVar instead.", format, "tester.Fatalf called with different message")
+		assertEquals(t, 2, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, true, strings.HasSuffix(args[0].(string), ".testLookup[T]"), "tester.Fatalf called with different argument 1")
+		assertEquals(t, reflect.Func, args[1].(reflect.Type).Kind(), "tester.Fatalf called with different argument 2")
+	}
+
+	// SUT + act
+	m.Mock(testLookup[string])
+	m.Stub(testLookup[string])
+	m.MockOn(&testResource{}, testLookup[*testResource])
+
+	// assert
+	assertEquals(t, 3, fatalfCalled, "tester.Fatalf called with different times")
+	assertEquals(t, "foo", testLookup("foo"), "testLookup call result different")
+}
+
+func TestMocker_ShouldMockGenericInstantiationThroughFunctionVariable(t *testing.T) {
+	// arrange
+	var lookup = testLookup[string]
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.MockFuncVar(&lookup).Expects("foo").Returns("bar").Once()
+
+	// SUT + act
+	var result1 = lookup("foo")
+	var result2 = testLookup(1)
+
+	// assert
+	assertEquals(t, "bar", result1, "lookup call result different")
+	assertEquals(t, 1, result2, "testLookup call result different")
+}
+
+func TestMocker_ShouldMockExportedMethodByNameWhenCallingMockMethod(t *testing.T) {
+	// arrange
+	var resource = &testResource{}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.MockMethod((*testResource)(nil), "Acquire").Expects(resource, 1).Returns(2).Once()
+
+	// SUT + act
+	var result = resource.Acquire(1)
+
+	// assert
+	assertEquals(t, 2, result, "Acquire call result different")
+	assertEquals(t, 1, m.CallCount((*testResource).Acquire), "Acquire call count different")
+}
+
+func TestMocker_ShouldStubExportedMethodByNameWhenCallingStubMethod(t *testing.T) {
+	// arrange
+	var resource = &testResource{}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.StubMethod((*testResource)(nil), "Acquire").Returns(3).Twice()
+
+	// SUT + act
+	var result1 = resource.Acquire(1)
+	var result2 = resource.Acquire(4)
+
+	// assert
+	assertEquals(t, 3, result1, "Acquire call result 1 different")
+	assertEquals(t, 3, result2, "Acquire call result 2 different")
+}
+
+func TestMocker_ShouldMockUnexportedMethodByNameWhenCallingMockMethod(t *testing.T) {
+	// arrange
+	var resource = &testResource{}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.MockMethod((*testResource)(nil), "release", (func(*testResource, int) int)(nil)).Expects(resource, 1).Returns(2).Once()
+
+	// SUT + act
+	var result = resource.release(1)
+
+	// assert
+	assertEquals(t, 2, result, "release call result different")
+	assertEquals(t, 1, m.CallCount((*testResource).release), "release call count different")
+}
+
+func TestMocker_ShouldStubUnexportedMethodByNameWhenCallingStubMethod(t *testing.T) {
+	// arrange
+	var resource = &testResource{}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.StubMethod((*testResource)(nil), "release", (func(*testResource, int) int)(nil)).Returns(3).Twice()
+
+	// SUT + act
+	var result1 = resource.release(1)
+	var result2 = resource.release(4)
+
+	// assert
+	assertEquals(t, 3, result1, "release call result 1 different")
+	assertEquals(t, 3, result2, "release call result 2 different")
+}
+
+func TestMocker_ShouldReportErrorIfSignatureInvalidForUnexportedMethodWhenCallingMockMethod(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+	var fatalfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		fatalfCalled++
+		assertEquals(t, "Unexpected signature %v passed for the unexported method [%v] of type [%T], which must be given"+
+			" as a single function value taking the receiver as the first parameter, e.g. (func(*foo, string) error)(nil)", format, "tester.Fatalf called with different message")
+		assertEquals(t, 3, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, "release", args[1], "tester.Fatalf called with different argument 2")
+	}
+
+	// SUT + act
+	m.MockMethod((*testResource)(nil), "release")
+	m.StubMethod((*testResource)(nil), "release", "foo")
+	m.MockMethod((*testResource)(nil), "release", (func(int) int)(nil))
+	m.StubMethod((*testResource)(nil), "release", (func(testResource, int) int)(nil))
+	m.MockMethod((*testResource)(nil), "release", (func(*testResource, int) int)(nil), (func(*testResource, int) int)(nil))
+
+	// assert
+	assertEquals(t, 5, fatalfCalled, "tester.Fatalf called with different times")
+	assertEquals(t, 0, len(m.(*mocker).entries), "mocker entries different")
+}
+
+func TestMocker_ShouldReportErrorIfSignatureMismatchedForExportedMethodWhenCallingMockMethod(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+	var fatalfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		fatalfCalled++
+		assertEquals(t, "Unexpected signature %v passed for the method [%v] of type [%T], which is of type %v", format, "tester.Fatalf called with different message")
+		assertEquals(t, 4, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, "Acquire", args[1], "tester.Fatalf called with different argument 2")
+		assertEquals(t, reflect.TypeOf((*testResource).Acquire), args[3], "tester.Fatalf called with different argument 4")
+	}
+
+	// SUT + act
+	m.MockMethod((*testResource)(nil), "Acquire", (func(*testResource, string) int)(nil))
+	m.StubMethod((*testResource)(nil), "Acquire", (*testResource).Acquire, (*testResource).Acquire)
+
+	// assert
+	assertEquals(t, 2, fatalfCalled, "tester.Fatalf called with different times")
+}
+
+func TestMocker_ShouldReportErrorIfMethodNotFoundWhenCallingMockMethod(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+	var fatalfCalled = 0
+	var expectNames = []string{"Missing", "Missing", "Missing", "Missing", "missing", "release"}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		fatalfCalled++
+		assertEquals(t, "The method [%v] of type [%T] cannot be found", format, "tester.Fatalf called with different message")
+		assertEquals(t, 2, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, expectNames[fatalfCalled-1], args[0], "tester.Fatalf called with different argument 1")
+	}
+
+	// SUT + act
+	m.MockMethod((*testResource)(nil), "Missing")
+	m.StubMethod(testResource{}, "Missing")
+	m.StubMethod(0, "Missing")
+	m.StubMethod(nil, "Missing")
+	m.MockMethod((*testResource)(nil), "missing", (func(*testResource) int)(nil))
+	m.StubMethod(testResource{}, "release", (func(testResource, int) int)(nil))
+
+	// assert
+	assertEquals(t, 6, fatalfCalled, "tester.Fatalf called with different times")
+}
+
+func TestMocker_ShouldFallBackToParentSetupsWhenCallingDerive(t *testing.T) {
+	// arrange
+	var log = func(string) {}
+	var send = func(string) error { return nil }
+	var now = func() int { return 0 }
+
+	// mock
+	var parent = NewMocker(t)
+	parent.Stub(log).Returns().AnyTimes()
+	parent.MockFuncVar(&now).Expects().Returns(1).AnyTimes()
+
+	// expect
+	var child = parent.Derive()
+	child.Mock(send).Expects("foo").Returns(errors.New("bar")).Once()
+
+	// SUT + act
+	log("foo")
+	var err = send("foo")
+	var result = now()
+
+	// assert
+	assertEquals(t, "bar", err.Error(), "send call error different")
+	assertEquals(t, 1, result, "now call result different")
+	assertEquals(t, 0, child.CallCount(log), "child log call count different")
+	assertEquals(t, 1, parent.CallCount(log), "parent log call count different")
+	assertEquals(t, 1, child.CallCount(send), "child send call count different")
+}
+
+func TestMocker_ShouldNotReplayParentMocksConsumedBeforeCallingDerive(t *testing.T) {
+	// arrange
+	var send = func(string) error { return nil }
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var parent = NewMocker(tester)
+	parent.Mock(send).Expects("foo").Returns(nil).Once()
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, true, strings.HasPrefix(format, "[%v] Unepxected number of calls: expect %v, actual %v with arguments (%v)"), "tester.Errorf called with different message")
+		assertEquals(t, 2, args[2], "tester.Errorf called with different argument 3")
+	}
+
+	// SUT
+	var child = parent.Derive()
+	var err = send("foo")
+
+	// act
+	send("foo")
+
+	// assert
+	assertEquals(t, nil, err, "send call error different")
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+	assertEquals(t, 0, child.CallCount(send), "child send call count different")
+}
+
+func TestMocker_ShouldVerifyDerivedMockerIndependently(t *testing.T) {
+	// arrange
+	var log = func(string) {}
+	var send = func(string) error { return nil }
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var parent = NewMocker(tester, WithReportUnusedStubs())
+	parent.Stub(log).Returns().AnyTimes()
+	var child = parent.Derive()
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 4, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 1, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, 0, args[2], "tester.Errorf called with different argument 3")
+	}
+	child.Mock(send).Expects("foo").Returns(nil).Once()
+	child.Mock(log).Expects("bar").Returns().Once()
+
+	// SUT
+	log("bar")
+
+	// act
+	child.(*mocker).verifyAll()
+	log("foo")
+	parent.(*mocker).verifyAll()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldPatchAndRestoreVariablesWhenCallingMockVar(t *testing.T) {
+	// arrange
+	var maxRetries = 3
+	var reader io.Reader
+	var tags = []string{"foo"}
+
+	// mock
+	var m = NewMocker(t)
+
+	// SUT + act
+	m.MockVar(&maxRetries, 5)
+	m.MockVar(&maxRetries, 7)
+	m.MockVar(&reader, strings.NewReader("bar"))
+	m.MockVar(&tags, nil)
+
+	// assert
+	assertEquals(t, 7, maxRetries, "maxRetries different after patched")
+	assertEquals(t, true, reader != nil, "reader different after patched")
+	assertEquals(t, 0, len(tags), "tags different after patched")
+	m.(*mocker).verifyAll()
+	assertEquals(t, 3, maxRetries, "maxRetries different after restored")
+	assertEquals(t, nil, reader, "reader different after restored")
+	assertEquals(t, "foo", tags[0], "tags different after restored")
+}
+
+func TestMocker_ShouldRestoreOriginalWhenCallingMockVarAfterMockFuncVar(t *testing.T) {
+	// arrange
+	var double = func(int) int { return 3 }
+	var fn = func(value int) int { return value }
+
+	// SUT + act
+	t.Run("patch", func(t *testing.T) {
+		// mock
+		var m = NewMocker(t)
+
+		// expect
+		m.MockFuncVar(&fn).Expects(1).Returns(2).Once()
+
+		// act
+		var result1 = fn(1)
+		m.MockVar(&fn, double)
+		var result2 = fn(1)
+
+		// assert
+		assertEquals(t, 2, result1, "fn call result 1 different")
+		assertEquals(t, 3, result2, "fn call result 2 different")
+	})
+	var result = fn(1)
+
+	// assert
+	assertEquals(t, 1, result, "fn call result different after restored")
+}
+
+func TestMocker_ShouldRestoreOriginalWhenCallingMockFuncVarAfterMockVar(t *testing.T) {
+	// arrange
+	var double = func(int) int { return 3 }
+	var fn = func(value int) int { return value }
+
+	// SUT + act
+	t.Run("patch", func(t *testing.T) {
+		// mock
+		var m = NewMocker(t)
+
+		// expect
+		m.MockVar(&fn, double)
+		m.MockFuncVar(&fn).Expects(1).Returns(2).Once()
+
+		// act
+		var result1 = fn(1)
+
+		// assert
+		assertEquals(t, 2, result1, "fn call result 1 different")
+	})
+	var result = fn(1)
+
+	// assert
+	assertEquals(t, 1, result, "fn call result different after restored")
+}
+
+func TestMocker_ShouldReportErrorIfInvalidArgumentsWhenCallingMockVar(t *testing.T) {
+	// arrange
+	var maxRetries = 3
+	var tester = &tester{t: t}
+	var fatalfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		fatalfCalled++
+		switch fatalfCalled {
+		case 1, 2:
+			assertEquals(t, "Unexpected target [%v] passed to MockVar, only a pointer to a variable is supported", format, "tester.Fatalf called with different message")
+			assertEquals(t, 1, len(args), "tester.Fatalf called with different number of args")
+		case 3, 4:
+			assertEquals(t, "Unexpected value [%v] passed to MockVar, which is not assignable to the variable of type %v", format, "tester.Fatalf called with different message")
+			assertEquals(t, 2, len(args), "tester.Fatalf called with different number of args")
+			assertEquals(t, reflect.TypeOf(0), args[1], "tester.Fatalf called with different argument 2")
+		case 5:
+			assertEquals(t, "Unexpected %v values passed to MockVar, only a single value is supported", format, "tester.Fatalf called with different message")
+			assertEquals(t, 2, args[0], "tester.Fatalf called with different argument 1")
+		}
+	}
+
+	// SUT + act
+	m.MockVar(maxRetries, 5)
+	m.MockVar((*int)(nil), 5)
+	m.MockVar(&maxRetries, "5")
+	m.MockVar(&maxRetries, nil)
+	m.MockVar(&maxRetries, 5, 7)
+
+	// assert
+	assertEquals(t, 5, fatalfCalled, "tester.Fatalf called with different times")
+	assertEquals(t, 3, maxRetries, "maxRetries different")
+}
+
+func TestMocker_ShouldMockFunctionVariableWhenCallingMockVarWithoutValue(t *testing.T) {
+	// arrange
+	var fetchUser = func(string) (string, error) { return "", nil }
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.MockVar(&fetchUser).Expects("foo").Returns("bar", nil).Once()
+
+	// SUT + act
+	var result, err = fetchUser("foo")
+
+	// assert
+	assertEquals(t, "bar", result, "fetchUser call result different")
+	assertEquals(t, nil, err, "fetchUser call error different")
+}
+
+func TestMocker_ShouldReportErrorIfVariablePatchedByAnotherTest(t *testing.T) {
+	// arrange
+	var maxRetries = 3
+	var fetchUser = func(string) (string, error) { return "", nil }
+	var tester = &tester{t: t, name: "TestOther"}
+	var fatalfCalled = 0
+
+	// mock
+	var owner = NewMocker(t)
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		fatalfCalled++
+		assertEquals(t, "The function or method [%v] is already patched by test [%v] while test [%v] tries to patch it."+
+			" Monkey patching is process-global, thus incompatible with t.Parallel for overlapping targets;"+
+			" avoid calling t.Parallel in tests patching the same functions or methods.", format, "tester.Fatalf called with different message")
+		assertEquals(t, t.Name(), args[1], "tester.Fatalf called with different argument 2")
+	}
+	owner.MockVar(&maxRetries, 5)
+	owner.MockFuncVar(&fetchUser).Expects("foo").Returns("bar", nil).AnyTimes()
+
+	// SUT + act
+	m.MockVar(&maxRetries, 7)
+	m.MockFuncVar(&fetchUser)
+
+	// assert
+	assertEquals(t, 2, fatalfCalled, "tester.Fatalf called with different times")
+	assertEquals(t, 5, maxRetries, "maxRetries different")
+}
+
+func TestMocker_ShouldReportErrorIfPatchNotAppliedWhenCallingMock(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+	var fatalfCalled = 0
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		fatalfCalled++
+		assertEquals(t, "The underlying function or method [%v] was not patched, thus calls to it would not reach the mocker."+
+			" Try adding //go:noinline to it, or running tests with -gcflags=all=-l to disable optimizations.", format, "tester.Fatalf called with different message")
+		assertEquals(t, 1, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester:  tester,
+		patches: &testPatcher{gomonkey.NewPatches()},
+		entries: make(map[uintptr]*funcEntry),
+		locker:  &sync.Mutex{},
+	}
+
+	// act
+	m.Mock(foo)
+
+	// assert
+	assertEquals(t, 1, fatalfCalled, "tester.Fatalf called with different times")
+}
+
+func TestMocker_ShouldReportErrorIfPatchedByAnotherTestWhenCallingMock(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t, name: "TestOther"}
+	var fatalfCalled = 0
+
+	// mock
+	var owner = NewMocker(t)
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		fatalfCalled++
+		assertEquals(t, "The function or method [%v] is already patched by test [%v] while test [%v] tries to patch it."+
+			" Monkey patching is process-global, thus incompatible with t.Parallel for overlapping targets;"+
+			" avoid calling t.Parallel in tests patching the same functions or methods.", format, "tester.Fatalf called with different message")
+		assertEquals(t, 3, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, t.Name(), args[1], "tester.Fatalf called with different argument 2")
+		assertEquals(t, "TestOther", args[2], "tester.Fatalf called with different argument 3")
+	}
+	owner.Stub(foo).Returns().AnyTimes()
+
+	// SUT + act
+	m.Stub(foo)
+
+	// assert
+	assertEquals(t, 1, fatalfCalled, "tester.Fatalf called with different times")
+}
+
+func TestMocker_ShouldAllowPatchingAgainAfterFormerTestReleased(t *testing.T) {
+	// arrange
+	var foo = func() int { return 0 }
+	var tester = &tester{t: t, name: "TestOther"}
+
+	// mock
+	var owner = NewMocker(tester)
+	owner.Stub(foo).Returns(1).AnyTimes()
+	owner.(*mocker).verifyAll()
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects().Returns(2).Once()
+
+	// SUT + act
+	var result = foo()
+
+	// assert
+	assertEquals(t, 2, result, "foo call result different")
+}
+
+func TestMocker_ShouldAllowSubtestPatchingSameTargetsAsParentTest(t *testing.T) {
+	// arrange
+	var foo = func() int { return 0 }
+
+	// mock
+	var m = NewMocker(t)
+	m.Stub(foo).Returns(1).AnyTimes()
+
+	t.Run("subtest", func(t *testing.T) {
+		// mock
+		var m = NewMocker(t)
+
+		// expect
+		m.Mock(foo).Expects().Returns(2).Once()
+
+		// SUT + act
+		var result = foo()
+
+		// assert
+		assertEquals(t, 2, result, "foo call result different")
+	})
+}
+
+type testResource struct {
+}
+
+func (r *testResource) Acquire(id int) int {
+	return id
+}
+
+func (r *testResource) release(id int) int {
+	return -id
+}
+
+func setupTestResource(t testing.TB, result int) Mocker {
+	var m = NewMocker(t)
+	m.Stub((*testResource).Acquire).Returns(result).AnyTimes()
+	return m
+}
+
+func TestMocker_ShouldReportErrorIfClosedBeforeLaterMockersOfSameTargets(t *testing.T) {
+	// arrange
+	var resource = &testResource{}
+	var tester = &tester{t: t}
+	var fatalfCalled = 0
+
+	// mock
+	var helper = setupTestResource(tester, 1)
+	var m = NewMocker(tester)
+
+	// expect
+	m.Stub((*testResource).Acquire).Returns(2).AnyTimes()
+	tester.fatalf = func(format string, args ...interface{}) {
+		fatalfCalled++
+		assertEquals(t, "The mocker of test [%v] is reset while %v later mocker(s) of tests %v still patch the same functions or methods,"+
+			" thus they are reset as well and call into the real functions from now on."+
+			" Reset or close the mockers in the reverse order of their creation.", format, "tester.Fatalf called with different message")
+		assertEquals(t, 3, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, 1, args[1], "tester.Fatalf called with different argument 2")
+	}
+
+	// SUT
+	var result1 = resource.Acquire(9)
+
+	// act
+	helper.Close()
+	var result2 = resource.Acquire(9)
+	m.Close()
+	var result3 = resource.Acquire(9)
+
+	// assert
+	assertEquals(t, 2, result1, "Acquire call result 1 different")
+	assertEquals(t, 9, result2, "Acquire call result 2 different")
+	assertEquals(t, 9, result3, "Acquire call result 3 different")
+	assertEquals(t, 1, fatalfCalled, "tester.Fatalf called with different times")
+}
+
+func TestMocker_ShouldResetPatchesInReverseOrderWhenClosedInReverseOrder(t *testing.T) {
+	// arrange
+	var resource = &testResource{}
+
+	// mock
+	var helper = setupTestResource(t, 1)
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub((*testResource).Acquire).Returns(2).AnyTimes()
+
+	// SUT
+	var result1 = resource.Acquire(9)
+
+	// act
+	m.Close()
+	var result2 = resource.Acquire(9)
+	helper.Close()
+	var result3 = resource.Acquire(9)
+
+	// assert
+	assertEquals(t, 2, result1, "Acquire call result 1 different")
+	assertEquals(t, 1, result2, "Acquire call result 2 different")
+	assertEquals(t, 9, result3, "Acquire call result 3 different")
+}
+
+func TestMocker_ShouldReportCallTimelineWhenExpectationFails(t *testing.T) {
+	// arrange
+	var write = func(string) {}
+	var commit = func() {}
+	var tester = &tester{t: t}
+	var logfCalled = 0
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {}
+	tester.logf = func(format string, args ...interface{}) {
+		logfCalled++
+		assertEquals(t, "Call timeline of the latest %v calls:\n%v", format, "tester.Logf called with different message")
+		assertEquals(t, 2, len(args), "tester.Logf called with different number of args")
+		assertEquals(t, 2, args[0], "tester.Logf called with different argument 1")
+		assertEquals(t, "#1 [commit] call #1 ()\n#2 [write] call #1 (abcdefghijklmnopqrstuvwxyzabcdef...)", args[1], "tester.Logf called with different argument 2")
+	}
+	m.Mock(write).Named("write").Expects(Anything()).Returns().Twice()
+	m.Mock(commit).Named("commit").Expects().Returns().Once()
+
+	// SUT + act
+	commit()
+	write(strings.Repeat("abcdefghijklmnopqrstuvwxyz", 2))
+	m.verifyAll()
+
+	// assert
+	assertEquals(t, 1, logfCalled, "tester.Logf called with different times")
+}
+
+func TestMocker_ShouldKeepLatestCallsInTimeline(t *testing.T) {
+	// arrange
+	var foo = func(int) {}
+
+	// mock
+	var m = NewMocker(t).(*mocker)
+
+	// expect
+	m.Mock(foo).Expects(Anything()).Returns().Times(timelineSize + 5)
+
+	// SUT + act
+	for i := 0; i < timelineSize+5; i++ {
+		foo(i)
+	}
+
+	// assert
+	assertEquals(t, timelineSize, len(m.timeline), "timeline length different")
+	assertEquals(t, 6, m.timeline[0].sequence, "timeline first sequence different")
+	assertEquals(t, "5", m.timeline[0].args, "timeline first args different")
+}
+
+func TestMocker_ShouldBoundHugeArgumentsInTimeline(t *testing.T) {
+	// arrange
+	var write = func(string, []byte) {}
+
+	// mock
+	var m = NewMocker(t).(*mocker)
+
+	// expect
+	m.Mock(write).Expects(Anything(), Anything()).Returns().Once()
+
+	// SUT + act
+	write(strings.Repeat("世界", 1<<20), make([]byte, 1<<20))
+
+	// assert
+	assertEquals(t, strings.Repeat("世界", 16)+"..., [0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0...", m.timeline[0].args, "timeline args different")
+}
+
+type testReentrant struct {
+	describe func() string
+}
+
+func (r testReentrant) String() string {
+	return r.describe()
+}
+
+func TestMocker_ShouldRenderArgumentsCallingMockedFunctionsInTimeline(t *testing.T) {
+	// arrange
+	var describe = func() string { return "" }
+	var handle = func(testReentrant) {}
+
+	// mock
+	var m = NewMocker(t).(*mocker)
+
+	// expect
+	m.Stub(describe).Returns("foo").AnyTimes()
+	m.Mock(handle).Expects(Anything()).Returns().Once()
+
+	// SUT + act
+	handle(testReentrant{describe: describe})
+
+	// assert
+	assertEquals(t, "foo", m.timeline[len(m.timeline)-1].args, "timeline args different")
+}
+
+func TestMocker_ShouldAssertCalledWithAfterExecution(t *testing.T) {
+	// arrange
+	var foo = func(string, int) {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(foo).Returns().AnyTimes()
+
+	// SUT
+	foo("a", 1)
+	foo("b", 2)
+
+	// act + assert
+	m.AssertCalledWith(foo, "b", Matches(func(value interface{}) bool {
+		return value.(int) > 1
+	}))
+}
+
+func TestMocker_ShouldAssertCalledWithForFunctionVariable(t *testing.T) {
+	// arrange
+	var bar = rand.Intn(100)
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.MockFuncVar(&testFuncVar).Expects(bar).Returns(bar).Once()
+
+	// SUT
+	testFuncVar(bar)
+
+	// act + assert
+	m.AssertCalledWith(&testFuncVar, bar)
+}
+
+func TestMocker_ShouldReportTestFailureIfNoCallMatchesWhenCallingAssertCalledWith(t *testing.T) {
+	// arrange
+	var foo = func(string, int) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Expect at least one call with parameters %v, closest call #%v %v: %v", format, "tester.Errorf called with different message")
+		assertEquals(t, 5, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "[c 2]", fmt.Sprint(args[1]), "tester.Errorf called with different argument 2")
+		assertEquals(t, 2, args[2], "tester.Errorf called with different argument 3")
+		assertEquals(t, "[b 2]", fmt.Sprint(args[3]), "tester.Errorf called with different argument 4")
+		assertEquals(t, "parameter #1 expect c, actual b", args[4], "tester.Errorf called with different argument 5")
+	}
+	m.Stub(foo).Returns().AnyTimes()
+
+	// SUT
+	foo("a", 1)
+	foo("b", 2)
+
+	// act
+	m.AssertCalledWith(foo, "c", 2)
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportTestFailureIfNeverSetupWhenCallingAssertCalledWith(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "The underlying function or method %v was never setup", format, "tester.Errorf called with different message")
+		assertEquals(t, 1, len(args), "tester.Errorf called with different number of args")
+	}
+
+	// act
+	m.AssertCalledWith(foo)
+	m.AssertCalledWith(&testFuncVar, 1)
+	m.AssertNotCalledWith(foo)
+
+	// assert
+	assertEquals(t, 3, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldVerifyMocksInPhases(t *testing.T) {
+	// arrange
+	var foo = func(int) int { return 0 }
+	var bar = func() {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(1).Returns(10).Once()
+	m.Stub(bar).Returns().AnyTimes()
+
+	// SUT + act
+	var first = foo(1)
+	bar()
+	m.Verify()
+
+	// expect
+	m.Mock(foo).Expects(2).Returns(20).Once()
+
+	// SUT + act
+	var second = foo(2)
+	bar()
+
+	// assert
+	assertEquals(t, 10, first, "first result different")
+	assertEquals(t, 20, second, "second result different")
+}
+
+func TestMocker_ShouldKeepCallHistoryWhenSetupAgainAfterVerify(t *testing.T) {
+	// arrange
+	var foo = func(int) int { return 0 }
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+	}
+	m.Mock(foo).Expects(1).Returns(10).Once()
+
+	// SUT
+	foo(1)
+	foo(2)
+
+	// act
+	m.Mock(foo).Expects(3).Returns(30).Once()
+	var result = foo(3)
+
+	// assert
+	assertEquals(t, 30, result, "foo call result different")
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+	assertEquals(t, 3, m.CallCount(foo), "foo call count different")
+	assertEquals(t, 2, m.CallsOf(foo)[1].Args[0], "foo call args different")
+}
+
+func TestMocker_ShouldNotReportTwiceWhenVerifyFailsBeforeCleanup(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 4, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 1, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, 0, args[2], "tester.Errorf called with different argument 3")
+	}
+	m.Mock(foo).Expects().Returns().Once()
+
+	// act
+	m.Verify()
+	m.verifyAll()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldStubFunctionWithZeroReturns(t *testing.T) {
+	// arrange
+	var foo = func() (int, string, error) { return 1, "a", errors.New("some error") }
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(foo).ReturnsZero().Once()
+
+	// SUT + act
+	var result1, result2, result3 = foo()
+
+	// assert
+	assertEquals(t, 0, result1, "result1 different")
+	assertEquals(t, "", result2, "result2 different")
+	assertEquals(t, nil, result3, "result3 different")
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingReturnsZero(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to ReturnsZero without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.ReturnsZero()
+}
+
+func TestMocker_ShouldReturnErrorIfExpectationsWereNotMet(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var bar = func() {}
+	var baz = func() {}
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester, WithoutCleanupVerification())
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		t.Errorf("tester.Errorf called unexpectedly: "+format, args...)
+	}
+	var _, _, line, _ = runtime.Caller(0)
+	m.Mock(foo).Named("foo").Expects().Returns().Twice()
+	m.Mock(bar).Named("bar").Expects().Returns().Once()
+	m.Mock(baz).Named("baz").Expects().Returns().AtLeast(1)
+
+	// SUT
+	foo()
+	bar()
+	bar()
+
+	// act
+	var err = m.ExpectationsWereMet()
+
+	// assert
+	assertEquals(t, fmt.Sprintf("[foo] Unepxected number of calls: expect 2, actual 1 (declared at gomocker_test.go:%v)\n"+
+		"[bar] Unepxected number of calls: expect 1, actual 2 (declared at gomocker_test.go:%v)\n"+
+		"[baz] Unepxected number of calls: expect at least 1, actual 0 (declared at gomocker_test.go:%v)", line+1, line+2, line+3), fmt.Sprint(err), "err different")
+}
+
+func TestMocker_ShouldReturnErrorIfExpectationsWereNotMetInArguments(t *testing.T) {
+	// arrange
+	var foo = func(int) {}
+	var bar = func(int) {}
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester, WithoutCleanupVerification())
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		t.Errorf("tester.Errorf called unexpectedly: "+format, args...)
+	}
+	m.Mock(foo).Named("foo").ExpectsAnyOrder([]any{1}, []any{2}).Returns().Twice()
+	m.Mock(bar).Named("bar").ExpectsDistinct().Returns().Twice()
+
+	// SUT
+	foo(1)
+	foo(3)
+	bar(1)
+	bar(1)
+
+	// act
+	var err = m.ExpectationsWereMet()
+
+	// assert
+	assertEquals(t, "[foo] Parameter mismatch in any order: missing [[2]], extra [[3]]\n"+
+		"[bar] Duplicate arguments at call #2: (1) same as call #1", fmt.Sprint(err), "err different")
+}
+
+func TestMocker_ShouldReturnErrorIfExpectationsWereNotMetInOrder(t *testing.T) {
+	// arrange
+	var open = func() {}
+	var write = func() {}
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester, WithoutCleanupVerification())
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		t.Errorf("tester.Errorf called unexpectedly: "+format, args...)
+	}
+	var openSetup = m.Mock(open).Named("open").Expects().Returns().Once()
+	m.Mock(write).Named("write").Expects().Returns().Once().Requires(openSetup)
+
+	// SUT
+	write()
+	open()
+
+	// act
+	var err = m.ExpectationsWereMet()
+
+	// assert
+	assertEquals(t, "[write] Unexpected call order at call #1: expect [open] to be called at least once before", fmt.Sprint(err), "err different")
+}
+
+func TestMocker_ShouldReturnNilIfExpectationsWereMet(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var bar = func() {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects().Returns().Once()
+	m.Stub(bar).Returns().Once()
+
+	// SUT
+	foo()
+
+	// act
+	var err = m.ExpectationsWereMet()
+
+	// assert
+	assertEquals(t, nil, err, "err different")
+}
+
+func TestMocker_ShouldMockFunctionWithPositionAwareMatcher(t *testing.T) {
+	// arrange
+	var foo = func(int, int, int) {}
+	var positions = []int{}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	var ascending = MatchesAt(func(index int, value int) bool {
+		positions = append(positions, index)
+		return value == index*10
+	})
+	m.Mock(foo).Expects(ascending, ascending, ascending).Returns().Once()
+
+	// SUT + act
+	foo(10, 20, 30)
+
+	// assert
+	assertEquals(t, "[1 2 3]", fmt.Sprint(positions), "positions different")
+}
+
+func TestMocker_ShouldReportTestFailureWhenPositionAwareMatcherFails(t *testing.T) {
+	// arrange
+	var foo = func(int, int, any) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Parameter mismatch at call #%v: %v of %v parameters matched\n\tparameter #%v: matchFunc failed on actual %v\n\tparameter #%v: expect type %v, actual %v of type %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 11, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 1, args[2], "tester.Errorf called with different argument 3")
+		assertEquals(t, 3, args[3], "tester.Errorf called with different argument 4")
+		assertEquals(t, 2, args[4], "tester.Errorf called with different argument 5")
+		assertEquals(t, 30, args[5], "tester.Errorf called with different argument 6")
+		assertEquals(t, 3, args[6], "tester.Errorf called with different argument 7")
+		assertEquals(t, "int", fmt.Sprint(args[7]), "tester.Errorf called with different argument 8")
+		assertEquals(t, "a", args[8], "tester.Errorf called with different argument 9")
+		assertEquals(t, "string", fmt.Sprint(args[9]), "tester.Errorf called with different argument 10")
+	}
+	var ascending = MatchesAt(func(index int, value int) bool {
+		return value == index*10
+	})
+	m.Mock(foo).Expects(ascending, ascending, ascending).Returns().Once()
+
+	// SUT + act
+	foo(10, 30, "a")
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldMatchNilInterfaceAsZeroValueWithPositionAwareMatcher(t *testing.T) {
+	// arrange
+	var foo = func(error) {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(MatchesAt(func(index int, value error) bool {
+		return index == 1 && value == nil
+	})).Returns().Once()
+
+	// SUT + act
+	foo(nil)
+}
+
+func TestMocker_ShouldReturnCallsOfMockedFunction(t *testing.T) {
+	// arrange
+	var fetch = func(offset int, tags ...string) (int, error) { return 0, nil }
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(fetch).Expects(0).Returns(10, nil).Once()
+	m.Mock(fetch).Expects(10, "a").Returns(20, nil).Once()
+
+	// SUT
+	var cursor, _ = fetch(0)
+	fetch(cursor, "a")
+
+	// act
+	var calls = m.CallsOf(fetch)
+
+	// assert
+	assertEquals(t, 2, len(calls), "calls length different")
+	assertEquals(t, 1, calls[0].Index, "calls[0].Index different")
+	assertEquals(t, "[0 []]", fmt.Sprint(calls[0].Args), "calls[0].Args different")
+	assertEquals(t, "[10 <nil>]", fmt.Sprint(calls[0].Returns), "calls[0].Returns different")
+	assertEquals(t, 2, calls[1].Index, "calls[1].Index different")
+	assertEquals(t, calls[0].Returns[0], calls[1].Args[0], "calls[1].Args[0] different")
+	assertEquals(t, "[10 [a]]", fmt.Sprint(calls[1].Args), "calls[1].Args different")
+	assertEquals(t, "[20 <nil>]", fmt.Sprint(calls[1].Returns), "calls[1].Returns different")
+}
+
+func TestMocker_ShouldReturnCallsOfStubbedFunction(t *testing.T) {
+	// arrange
+	var foo = func(bar []int) {}
+	var bar = []int{1, 2}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(foo).Returns().AnyTimes()
+
+	// SUT
+	foo(bar)
+
+	// act
+	var calls = m.CallsOf(foo)
+
+	// assert
+	assertEquals(t, 1, len(calls), "calls length different")
+	assertEquals(t, "[[1 2]]", fmt.Sprint(calls[0].Args), "calls[0].Args different")
+	assertEquals(t, 0, len(calls[0].Returns), "calls[0].Returns length different")
+}
+
+func TestMocker_ShouldReportTestFailureIfNeverSetupWhenCallingCallsOf(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "The underlying function or method %v was never setup", format, "tester.Errorf called with different message")
+	}
+
+	// act
+	var calls = m.CallsOf(foo)
+
+	// assert
+	assertEquals(t, 0, len(calls), "calls length different")
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReturnReturnedValuesOfMockedFunction(t *testing.T) {
+	// arrange
+	var foo = func(int) (int, error) { return 0, nil }
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(Anything()).Returns(0, dummyError).ConditionalReturn(func(args []any) ([]any, bool) {
+		var value = args[0].(int)
+		return []any{value * 2, nil}, value > 0
+	}).Times(3)
+
+	// SUT + act
+	foo(1)
+	foo(0)
+	foo(3)
+	var values = m.ReturnedValues(foo)
+
+	// assert
+	assertEquals(t, 3, len(values), "returned values length different")
+	assertEquals(t, 2, values[0][0], "returned value 1 different")
+	assertEquals(t, nil, values[0][1], "returned error 1 different")
+	assertEquals(t, 0, values[1][0], "returned value 2 different")
+	assertEquals(t, dummyError, values[1][1], "returned error 2 different")
+	assertEquals(t, 6, values[2][0], "returned value 3 different")
+	assertEquals(t, nil, values[2][1], "returned error 3 different")
+}
+
+func TestMocker_ShouldReportTestFailureIfNeverSetupWhenCallingReturnedValues(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "The underlying function or method %v was never setup", format, "tester.Errorf called with different message")
+	}
+
+	// act
+	var values = m.ReturnedValues(foo)
+
+	// assert
+	assertEquals(t, 0, len(values), "values length different")
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldMockStandardLibraryPointerMethod(t *testing.T) {
+	// arrange
+	var buffer = &bytes.Buffer{}
+	var dummyError = errors.New("some error")
+	var save = func(writer *bytes.Buffer, content string) error {
+		var _, err = writer.WriteString(content)
+		return err
+	}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock((*bytes.Buffer).WriteString).Expects(buffer, "some content").Returns(4, dummyError).Once()
+
+	// SUT + act
+	var err = save(buffer, "some content")
+
+	// assert
+	assertEquals(t, dummyError, err, "err different")
+	assertEquals(t, 0, buffer.Len(), "buffer length different")
+}
+
+func TestMocker_ShouldReturnTotalCalls(t *testing.T) {
+	// arrange
+	var foo = func(int) {}
+	var bar = func() {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(Anything()).Returns().Times(3)
+	m.Stub(bar).Returns().AnyTimes()
+
+	// SUT
+	foo(1)
+	bar()
+	foo(2)
+	bar()
+	foo(3)
+
+	// act
+	var total = m.TotalCalls()
+
+	// assert
+	assertEquals(t, 5, total, "total calls different")
+}
+
+func TestMocker_ShouldReturnSatisfiedAfterExpectedCalls(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var bar = func() {}
+	var baz = func() {}
+	var calls = 0
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects().Returns().Times(3)
+	m.Mock(bar).Expects().Returns().AtLeast(1)
+
+	// SUT + act
+	for !m.Satisfied(foo) {
+		foo()
+		calls++
+	}
+	var barBefore = m.Satisfied(bar)
+	bar()
+	var barAfter = m.Satisfied(bar)
+
+	// assert
+	assertEquals(t, 3, calls, "calls different")
+	assertEquals(t, false, barBefore, "bar satisfied before call different")
+	assertEquals(t, true, barAfter, "bar satisfied after call different")
+	assertEquals(t, false, m.Satisfied(baz), "baz satisfied different")
+}
+
+func TestMocker_ShouldWaitForAsynchronousCalls(t *testing.T) {
+	// arrange
+	var foo = func(int) {}
+	var start = make(chan int)
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(foo).Returns().AnyTimes()
+
+	// SUT
+	go func() {
+		for count := range start {
+			for i := 0; i < count; i++ {
+				time.Sleep(time.Millisecond)
+				foo(i)
+			}
+		}
+	}()
+	defer close(start)
+
+	// act
+	start <- 2
+	var first = m.WaitForCall(foo, 2, time.Second)
+	start <- 3
+	var second = m.WaitForCall(foo, 5, time.Second)
+
+	// assert
+	assertEquals(t, true, first, "first wait different")
+	assertEquals(t, true, second, "second wait different")
+	assertEquals(t, 5, m.CallCount(foo), "call count different")
+}
+
+func TestMocker_ShouldReportTestFailureWhenWaitForCallTimesOut(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Timed out waiting for calls after %v: expect %v, actual %v", format, "tester.Errorf called with different message")
+		assertEquals(t, 4, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "foo", args[0], "tester.Errorf called with different argument 1")
+		assertEquals(t, 10*time.Millisecond, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, 2, args[2], "tester.Errorf called with different argument 3")
+		assertEquals(t, 1, args[3], "tester.Errorf called with different argument 4")
+	}
+	m.Mock(foo).Named("foo").Expects().Returns().AnyTimes()
+
+	// SUT
+	foo()
+
+	// act
+	var result = m.WaitForCall(foo, 2, 10*time.Millisecond)
+
+	// assert
+	assertEquals(t, false, result, "result different")
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReturnCallCount(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var bar = func() {}
+	var baz = func() {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(foo).Returns().Once()
+	m.Mock(bar).Expects().Returns().AnyTimes()
+
+	// SUT
+	foo()
+	foo()
+	bar()
+
+	// act
+	var fooCount = m.CallCount(foo)
+	var barCount = m.CallCount(bar)
+	var bazCount = m.CallCount(baz)
+
+	// assert
+	assertEquals(t, 2, fooCount, "foo call count different")
+	assertEquals(t, 1, barCount, "bar call count different")
+	assertEquals(t, 0, bazCount, "baz call count different")
+}
+
+func TestMocker_ShouldReportTestFailureIfNoCallWhenCallingAssertCalledWith(t *testing.T) {
+	// arrange
+	var foo = func(int) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Expect at least one call with parameters %v, actual no call", format, "tester.Errorf called with different message")
+		assertEquals(t, 2, len(args), "tester.Errorf called with different number of args")
+	}
+	m.Stub(foo).Returns().AnyTimes()
+
+	// act
+	m.AssertCalledWith(foo, 1)
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportParameterCountInClosestCallWhenCallingAssertCalledWith(t *testing.T) {
+	// arrange
+	var foo = func(...int) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, 5, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "expect 1 parameters, actual 2", args[4], "tester.Errorf called with different argument 5")
+	}
+	m.Stub(foo).Returns().AnyTimes()
+
+	// SUT
+	foo(1, 2)
+
+	// act
+	m.AssertCalledWith(foo, 1)
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldAssertNotCalledWithAfterExecution(t *testing.T) {
+	// arrange
+	var foo = func(string) {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(foo).Returns().AnyTimes()
+
+	// SUT
+	foo("a")
+
+	// act + assert
+	m.AssertNotCalledWith(foo, "b")
+}
+
+func TestMocker_ShouldReportTestFailureIfCallMatchesWhenCallingAssertNotCalledWith(t *testing.T) {
+	// arrange
+	var foo = func(string) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Expect no call with parameters %v, actual call #%v %v", format, "tester.Errorf called with different message")
+		assertEquals(t, 4, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 2, args[2], "tester.Errorf called with different argument 3")
+		assertEquals(t, "[b]", fmt.Sprint(args[3]), "tester.Errorf called with different argument 4")
+	}
+	m.Stub(foo).Returns().AnyTimes()
+
+	// SUT
+	foo("a")
+	foo("b")
+
+	// act
+	m.AssertNotCalledWith(foo, "b")
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldAssertNothingCalledWhenShortCircuited(t *testing.T) {
+	// arrange
+	var foo = func(string) {}
+	var bar = func() int { return 0 }
+	var sut = func(valid bool) {
+		if !valid {
+			return
+		}
+		foo("a")
+		bar()
+	}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(foo).Returns().AnyTimes()
+	m.Stub(bar).Returns(1).AnyTimes()
+
+	// SUT
+	sut(false)
+
+	// act + assert
+	m.AssertNothingCalled()
+}
+
+func TestMocker_ShouldReportTestFailureIfAnythingCalledWhenCallingAssertNothingCalled(t *testing.T) {
+	// arrange
+	var foo = func(string) {}
+	var bar = func() int { return 0 }
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "Expect no call to any function or method, actual calls to %v", format, "tester.Errorf called with different message")
+		assertEquals(t, 1, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, true, strings.HasSuffix(args[0].(string), "] x2"), "tester.Errorf called with different argument 1")
+		assertEquals(t, 1, strings.Count(args[0].(string), "["), "tester.Errorf called with different argument 1")
+	}
+	m.Stub(foo).Returns().AnyTimes()
+	m.Stub(bar).Returns(1).AnyTimes()
+
+	// SUT
+	foo("a")
+	foo("b")
+
+	// act
+	m.AssertNothingCalled()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+type testInstance struct {
+	id int
+}
+
+func (o *testInstance) Value(bar int) int {
+	return o.id
+}
+
+func TestMocker_ShouldMockStructMethodOnDifferentInstances(t *testing.T) {
+	// arrange
+	var first = &testInstance{id: 1}
+	var second = &testInstance{id: 2}
+	var third = &testInstance{id: 3}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.MockOn(first, (*testInstance).Value).Expects(first, 10).Returns(100).Once()
+	m.MockOn(second, (*testInstance).Value).Expects(second, 20).Returns(200).Twice()
+	m.Stub((*testInstance).Value).Returns(300).Once()
+
+	// SUT + act
+	var result1 = first.Value(10)
+	var result2 = second.Value(20)
+	var result3 = third.Value(30)
+	var result4 = second.Value(20)
+
+	// assert
+	assertEquals(t, 100, result1, "first.Value call result different")
+	assertEquals(t, 200, result2, "second.Value call result different")
+	assertEquals(t, 300, result3, "third.Value call result different")
+	assertEquals(t, 200, result4, "second.Value call result different")
+}
+
+func TestMocker_ShouldReportTestFailureWhenMockedInstanceIsNotCalled(t *testing.T) {
+	// arrange
+	var first = &testInstance{id: 1}
+	var second = &testInstance{id: 2}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 4, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, true, strings.HasSuffix(fmt.Sprint(args[0]), fmt.Sprintf(" on %p", second)), "tester.Errorf called with different argument 1")
+	}
+	m.MockOn(first, (*testInstance).Value).Expects(first, 10).Returns(100).Once()
+	m.MockOn(second, (*testInstance).Value).Expects(second, 20).Returns(200).Once()
+
+	// SUT
+	first.Value(10)
+
+	// act
+	m.verifyAll()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportErrorIfInstanceIsInvalidWhenCallingMockOn(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected instance [%v] passed to MockOn, only a pointer receiver of method [%v] is supported", format, "tester.Fatalf called with different message")
+		assertEquals(t, 2, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, 1, args[0], "tester.Fatalf called with different argument 1")
+	}
+
+	// SUT + act
+	m.MockOn(1, (*testInstance).Value)
+}
+
+func TestMocker_ShouldReturnUnmetExpectations(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var bar = func() {}
+	var baz = func() {}
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester, WithoutCleanupVerification())
+
+	// expect
+	m.Mock(foo).Named("foo").Expects().Returns().Once()
+	m.Mock(bar).Named("bar").Expects().Returns().Once()
+	m.Mock(baz).Named("baz").Expects().Returns().Once()
+
+	// SUT
+	bar()
+
+	// act
+	var unmet = m.UnmetExpectations()
+
+	// assert
+	assertEquals(t, "[foo baz]", fmt.Sprint(unmet), "unmet expectations different")
+	assertEquals(t, "[foo baz]", fmt.Sprint(m.UnmetExpectations()), "unmet expectations different on second call")
+}
+
+func TestMocker_ShouldReportDeclarationOfUnmetSetup(t *testing.T) {
+	// arrange
+	var foo = func(int) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	var _, _, line, _ = runtime.Caller(0)
+	m.Mock(foo).Expects(1).Returns().Once()
+	m.Mock(foo).Expects(2).Returns().Once()
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		switch errorfCalled {
+		case 1:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, fmt.Sprint("gomocker_test.go:", line+1), args[5], "tester.Errorf called with different argument 6")
+		case 2:
+			assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, fmt.Sprint("gomocker_test.go:", line+2), args[3], "tester.Errorf called with different argument 4")
+		}
+	}
+
+	// SUT
+	foo(3)
+
+	// act
+	m.verifyAll()
+
+	// assert
+	assertEquals(t, 2, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldRestoreFunctionWhenClosedBeforeCleanup(t *testing.T) {
+	// arrange
+	var foo = func() int { return 1 }
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(foo).Returns(2).Once()
+
+	// SUT
+	var mocked = foo()
+
+	// act
+	m.Close()
+	m.Close()
+	var restored = foo()
+
+	// assert
+	assertEquals(t, 2, mocked, "mocked result different")
+	assertEquals(t, 1, restored, "restored result different")
+}
+
+func TestMocker_ShouldMockFunctionInTypedWay(t *testing.T) {
+	// arrange
+	var resource = &testResource{}
+	var parse = func(string) (int, error) { return 0, nil }
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	Mock2x1(m, (*testResource).Acquire).Expects(resource, 1).Returns(10).Once()
+	Mock1x2(m, parse).Named("parse").ExpectsMatch(Anything()).ReturnsError(dummyError).Once()
+	Mock1x2(m, parse).Named("parse").Expects("2").Returns(2, nil).Once()
+
+	// SUT + act
+	var result = resource.Acquire(1)
+	var value1, err1 = parse("1")
+	var value2, err2 = parse("2")
+
+	// assert
+	assertEquals(t, 10, result, "Acquire call result different")
+	assertEquals(t, 0, value1, "parse call value 1 different")
+	assertEquals(t, dummyError, err1, "parse call error 1 different")
+	assertEquals(t, 2, value2, "parse call value 2 different")
+	assertEquals(t, nil, err2, "parse call error 2 different")
+}
+
+func TestMocker_ShouldMockFunctionsOfAllAritiesInTypedWay(t *testing.T) {
+	// arrange
+	var format = func(value any) string { return fmt.Sprint(value) }
+	var f0x0 = func() {}
+	var f0x1 = func() string { return "" }
+	var f0x2 = func() (string, string) { return "", "" }
+	var f0x3 = func() (string, string, string) { return "", "", "" }
+	var f1x0 = func(int) {}
+	var f1x1 = func(int) string { return "" }
+	var f1x2 = func(int) (string, string) { return "", "" }
+	var f1x3 = func(int) (string, string, string) { return "", "", "" }
+	var f2x0 = func(int, int) {}
+	var f2x1 = func(int, int) string { return "" }
+	var f2x2 = func(int, int) (string, string) { return "", "" }
+	var f2x3 = func(int, int) (string, string, string) { return "", "", "" }
+	var f3x0 = func(int, int, int) {}
+	var f3x1 = func(int, int, int) string { return "" }
+	var f3x2 = func(int, int, int) (string, string) { return "", "" }
+	var f3x3 = func(int, int, int) (string, string, string) { return "", "", "" }
+	var f4x0 = func(int, int, int, int) {}
+	var f4x1 = func(int, int, int, int) string { return "" }
+	var f4x2 = func(int, int, int, int) (string, string) { return "", "" }
+	var f4x3 = func(int, int, int, int) (string, string, string) { return "", "", "" }
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	Mock0x0(m, f0x0).Named("f0x0").Labeled("f0x0").Unordered().Formatted(format).Expects().Returns().Once()
+	Mock0x1(m, f0x1).Named("f0x1").Labeled("f0x1").Unordered().Formatted(format).Expects().Returns("f0x1").Once()
+	Mock0x2(m, f0x2).Named("f0x2").Labeled("f0x2").Unordered().Formatted(format).Expects().Returns("f0x2", "f0x2").Once()
+	Mock0x3(m, f0x3).Named("f0x3").Labeled("f0x3").Unordered().Formatted(format).Expects().Returns("f0x3", "f0x3", "f0x3").Once()
+	Mock1x0(m, f1x0).Named("f1x0").Labeled("f1x0").Unordered().Formatted(format).Expects(1).Returns().Once()
+	Mock1x0(m, f1x0).ExpectsMatch(Anything()).Labeled("f1x0").Returns().Once()
+	Mock1x1(m, f1x1).Named("f1x1").Labeled("f1x1").Unordered().Formatted(format).Expects(1).Returns("f1x1").Once()
+	Mock1x1(m, f1x1).ExpectsMatch(Anything()).Labeled("f1x1").Returns("f1x1").Once()
+	Mock1x2(m, f1x2).Named("f1x2").Labeled("f1x2").Unordered().Formatted(format).Expects(1).Returns("f1x2", "f1x2").Once()
+	Mock1x2(m, f1x2).ExpectsMatch(Anything()).Labeled("f1x2").Returns("f1x2", "f1x2").Once()
+	Mock1x3(m, f1x3).Named("f1x3").Labeled("f1x3").Unordered().Formatted(format).Expects(1).Returns("f1x3", "f1x3", "f1x3").Once()
+	Mock1x3(m, f1x3).ExpectsMatch(Anything()).Labeled("f1x3").Returns("f1x3", "f1x3", "f1x3").Once()
+	Mock2x0(m, f2x0).Named("f2x0").Labeled("f2x0").Unordered().Formatted(format).Expects(1, 2).Returns().Once()
+	Mock2x0(m, f2x0).ExpectsMatch(Anything(), Anything()).Labeled("f2x0").Returns().Once()
+	Mock2x1(m, f2x1).Named("f2x1").Labeled("f2x1").Unordered().Formatted(format).Expects(1, 2).Returns("f2x1").Once()
+	Mock2x1(m, f2x1).ExpectsMatch(Anything(), Anything()).Labeled("f2x1").Returns("f2x1").Once()
+	Mock2x2(m, f2x2).Named("f2x2").Labeled("f2x2").Unordered().Formatted(format).Expects(1, 2).Returns("f2x2", "f2x2").Once()
+	Mock2x2(m, f2x2).ExpectsMatch(Anything(), Anything()).Labeled("f2x2").Returns("f2x2", "f2x2").Once()
+	Mock2x3(m, f2x3).Named("f2x3").Labeled("f2x3").Unordered().Formatted(format).Expects(1, 2).Returns("f2x3", "f2x3", "f2x3").Once()
+	Mock2x3(m, f2x3).ExpectsMatch(Anything(), Anything()).Labeled("f2x3").Returns("f2x3", "f2x3", "f2x3").Once()
+	Mock3x0(m, f3x0).Named("f3x0").Labeled("f3x0").Unordered().Formatted(format).Expects(1, 2, 3).Returns().Once()
+	Mock3x0(m, f3x0).ExpectsMatch(Anything(), Anything(), Anything()).Labeled("f3x0").Returns().Once()
+	Mock3x1(m, f3x1).Named("f3x1").Labeled("f3x1").Unordered().Formatted(format).Expects(1, 2, 3).Returns("f3x1").Once()
+	Mock3x1(m, f3x1).ExpectsMatch(Anything(), Anything(), Anything()).Labeled("f3x1").Returns("f3x1").Once()
+	Mock3x2(m, f3x2).Named("f3x2").Labeled("f3x2").Unordered().Formatted(format).Expects(1, 2, 3).Returns("f3x2", "f3x2").Once()
+	Mock3x2(m, f3x2).ExpectsMatch(Anything(), Anything(), Anything()).Labeled("f3x2").Returns("f3x2", "f3x2").Once()
+	Mock3x3(m, f3x3).Named("f3x3").Labeled("f3x3").Unordered().Formatted(format).Expects(1, 2, 3).Returns("f3x3", "f3x3", "f3x3").Once()
+	Mock3x3(m, f3x3).ExpectsMatch(Anything(), Anything(), Anything()).Labeled("f3x3").Returns("f3x3", "f3x3", "f3x3").Once()
+	Mock4x0(m, f4x0).Named("f4x0").Labeled("f4x0").Unordered().Formatted(format).Expects(1, 2, 3, 4).Returns().Once()
+	Mock4x0(m, f4x0).ExpectsMatch(Anything(), Anything(), Anything(), Anything()).Labeled("f4x0").Returns().Once()
+	Mock4x1(m, f4x1).Named("f4x1").Labeled("f4x1").Unordered().Formatted(format).Expects(1, 2, 3, 4).Returns("f4x1").Once()
+	Mock4x1(m, f4x1).ExpectsMatch(Anything(), Anything(), Anything(), Anything()).Labeled("f4x1").Returns("f4x1").Once()
+	Mock4x2(m, f4x2).Named("f4x2").Labeled("f4x2").Unordered().Formatted(format).Expects(1, 2, 3, 4).Returns("f4x2", "f4x2").Once()
+	Mock4x2(m, f4x2).ExpectsMatch(Anything(), Anything(), Anything(), Anything()).Labeled("f4x2").Returns("f4x2", "f4x2").Once()
+	Mock4x3(m, f4x3).Named("f4x3").Labeled("f4x3").Unordered().Formatted(format).Expects(1, 2, 3, 4).Returns("f4x3", "f4x3", "f4x3").Once()
+	Mock4x3(m, f4x3).ExpectsMatch(Anything(), Anything(), Anything(), Anything()).Labeled("f4x3").Returns("f4x3", "f4x3", "f4x3").Once()
+
+	// SUT + act
+	f0x0()
+	f0x1()
+	f0x2()
+	f0x3()
+	f1x0(1)
+	f1x0(1)
+	f1x1(1)
+	f1x1(1)
+	f1x2(1)
+	f1x2(1)
+	f1x3(1)
+	f1x3(1)
+	f2x0(1, 2)
+	f2x0(1, 2)
+	f2x1(1, 2)
+	f2x1(1, 2)
+	f2x2(1, 2)
+	f2x2(1, 2)
+	f2x3(1, 2)
+	f2x3(1, 2)
+	f3x0(1, 2, 3)
+	f3x0(1, 2, 3)
+	f3x1(1, 2, 3)
+	f3x1(1, 2, 3)
+	f3x2(1, 2, 3)
+	f3x2(1, 2, 3)
+	f3x3(1, 2, 3)
+	f3x3(1, 2, 3)
+	f4x0(1, 2, 3, 4)
+	f4x0(1, 2, 3, 4)
+	f4x1(1, 2, 3, 4)
+	f4x1(1, 2, 3, 4)
+	f4x2(1, 2, 3, 4)
+	f4x2(1, 2, 3, 4)
+	f4x3(1, 2, 3, 4)
+	f4x3(1, 2, 3, 4)
+}
+
+func TestMocker_ShouldIsolateScopedMockersOfSubtests(t *testing.T) {
+	// arrange
+	var foo = func() int { return 0 }
+	var bar = func() int { return 0 }
+
+	// mock
+	var m = NewMocker(t, WithStrictOrder())
+
+	// expect
+	m.Stub(bar).Returns(3).AnyTimes()
+
+	t.Run("first", func(t *testing.T) {
+		// mock
+		var scoped = m.Scope(t)
+
+		// expect
+		scoped.Mock(foo).Expects().Returns(1).Once()
+
+		// SUT + act
+		var result = foo()
+
+		// assert
+		assertEquals(t, 1, result, "foo call result different")
+		assertEquals(t, true, scoped.(*mocker).strict, "scoped mocker options different")
+		assertEquals(t, 0, scoped.CallCount(bar), "scoped mocker bar call count different")
+	})
+	var between = foo()
+
+	t.Run("second", func(t *testing.T) {
+		// mock
+		var scoped = m.Scope(t)
+
+		// expect
+		scoped.Mock(foo).Expects().Returns(2).Once()
+
+		// SUT + act
+		var result = foo()
+
+		// assert
+		assertEquals(t, 2, result, "foo call result different")
+		assertEquals(t, 3, bar(), "bar call result different")
+	})
+
+	// act
+	var after = foo()
+
+	// assert
+	assertEquals(t, 0, between, "foo call result different between subtests")
+	assertEquals(t, 0, after, "foo call result different after subtests")
+	assertEquals(t, 0, m.CallCount(foo), "foo call count different")
+	assertEquals(t, 1, m.CallCount(bar), "bar call count different")
+}
+
+func TestMocker_ShouldVerifyOnlyOnceWhenClosedMultipleTimes(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+	}
+	m.Mock(foo).Expects().Returns().Once()
+
+	// act
+	func() {
+		defer m.Close()
+	}()
+	m.Close()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldLogOnceWhenCalledAfterTestCompletion(t *testing.T) {
+	// arrange
+	var foo = func(int) int { return 0 }
+	var tester = &tester{t: t}
+	var logfCalled = 0
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	tester.logf = func(format string, args ...interface{}) {
+		logfCalled++
+		assertEquals(t, "[%v] Mocked function or method called after test completion, possibly from a leaked goroutine", format, "tester.Logf called with different message")
+		assertEquals(t, 1, len(args), "tester.Logf called with different number of args")
+		assertEquals(t, "foo", args[0], "tester.Logf called with different argument 1")
+	}
+	m.Stub(foo).Returns(1).AnyTimes()
+	var funcPtr, _ = m.getFuncPointer(foo)
+	var leaked = m.makeFunc("foo", funcPtr, reflect.TypeOf(foo)).Interface().(func(int) int)
+	m.Close()
+
+	// SUT + act
+	var result1 = leaked(1)
+	var result2 = leaked(2)
+
+	// assert
+	assertEquals(t, 0, result1, "leaked call result 1 different")
+	assertEquals(t, 0, result2, "leaked call result 2 different")
+	assertEquals(t, 1, logfCalled, "tester.Logf called with different times")
+}
+
+func TestMocker_ShouldNotPanicWhenCalledAfterTesterFinished(t *testing.T) {
+	// arrange
+	var foo = func() int { return 0 }
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	tester.logf = func(format string, args ...interface{}) {
+		panic("Log in goroutine after test has completed")
+	}
+	m.Stub(foo).Returns(1).AnyTimes()
+	var funcPtr, _ = m.getFuncPointer(foo)
+	var leaked = m.makeFunc("foo", funcPtr, reflect.TypeOf(foo)).Interface().(func() int)
+	m.Close()
+
+	// SUT + act
+	var result = leaked()
+
+	// assert
+	assertEquals(t, 0, result, "leaked call result different")
+}
+
+func TestMocker_ShouldReportLabelInFailureMessages(t *testing.T) {
+	// arrange
+	var fetch = func(string) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	m.Mock(fetch).Named("fetchUser").Expects("a").Returns().Once().Labeled("cache hit case")
+	m.Mock(fetch).Expects("b").Returns().Once().Labeled("cache miss case")
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		switch errorfCalled {
+		case 1:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, "fetchUser / 'cache hit case'", args[0], "tester.Errorf called with different argument 1")
+		case 2:
+			assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, "fetchUser / 'cache miss case'", args[0], "tester.Errorf called with different argument 1")
+		}
+	}
+
+	// SUT
+	fetch("c")
+
+	// act
+	m.verifyAll()
+
+	// assert
+	assertEquals(t, 2, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportLabelAttachedAtEachStageInFailureMessages(t *testing.T) {
+	// arrange
+	var fetch = func(string) {}
+	var load = func(string) {}
+	var save = func(string) {}
+	var tester = &tester{t: t}
+	var labels []interface{}
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	m.Mock(fetch).Labeled("expecter case").Expects("a").Returns().Once()
+	m.Mock(load).Expects("b").Labeled("returner case").Returns().Once()
+	m.Mock(save).Expects("c").Returns().Labeled("counter case").Once()
+	tester.errorf = func(format string, args ...interface{}) {
+		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		labels = append(labels, args[0])
+	}
+
+	// act
+	m.verifyAll()
+
+	// assert
+	assertEquals(t, 3, len(labels), "tester.Errorf called with different times")
+	assertEquals(t, true, strings.HasSuffix(fmt.Sprint(labels[0]), " / 'expecter case'"), "label of Expecter stage different")
+	assertEquals(t, true, strings.HasSuffix(fmt.Sprint(labels[1]), " / 'returner case'"), "label of Returner stage different")
+	assertEquals(t, true, strings.HasSuffix(fmt.Sprint(labels[2]), " / 'counter case'"), "label of Counter stage different")
+}
+
+func TestMocker_ShouldReportErrorIfNoSetupWhenCallingLabeledAtEachStage(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+	var fatalfCalled = 0
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		fatalfCalled++
+		assertEquals(t, "Unexpected call to Labeled without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.Labeled("dummy")
+	(&returner{m}).Labeled("dummy")
+	(&counter{m}).Labeled("dummy")
+
+	// assert
+	assertEquals(t, 3, fatalfCalled, "tester.Fatalf called with different times")
+}
+
+func TestMocker_ShouldWaitForAsynchronousCallsWithinTimeoutAtVerification(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		t.Errorf("tester.Errorf called unexpectedly: "+format, args...)
+	}
+	m.Mock(foo).Expects().Returns().Twice().Within(time.Second)
+
+	// SUT
+	go func() {
+		for i := 0; i < 2; i++ {
+			time.Sleep(10 * time.Millisecond)
+			foo()
+		}
+	}()
+
+	// act
+	m.verifyAll()
+
+	// assert
+	assertEquals(t, false, m.failed.Load(), "failed different")
+}
+
+func TestMocker_ShouldReportTestFailureWhenCallsNotMadeWithinTimeout(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Unepxected number of calls: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 1, args[1], "tester.Errorf called with different argument 2")
+		assertEquals(t, 0, args[2], "tester.Errorf called with different argument 3")
+	}
+	m.Mock(foo).Expects().Returns().Once().Within(10 * time.Millisecond)
+	var start = time.Now()
+
+	// act
+	m.verifyAll()
+
+	// assert
+	assertEquals(t, true, time.Since(start) >= 10*time.Millisecond, "verification duration different")
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportErrorIfNoCompletedSetupWhenCallingWithin(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to Within without completing a setup using Once/Twice/Times/AtLeast/AnyTimes", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.incomplete().Within(time.Second)
+}
+
+func TestMocker_ShouldReportErrorIfNoCompletedSetupWhenCallingLabeled(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to Labeled without completing a setup using Once/Twice/Times/AtLeast/AnyTimes", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.incomplete().Labeled("dummy")
+}
+
+type testLimiter struct {
+	name   string
+	tokens int
+	mutex  *sync.Mutex
+}
+
+func TestMocker_ShouldMockFunctionWithUnexportedFieldsParametersViaMatches(t *testing.T) {
+	// arrange
+	var foo = func(time.Time, testLimiter) {}
+	var now = time.Now()
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(
+		Matches(func(value interface{}) bool {
+			return value.(time.Time).Equal(now.Round(0))
+		}),
+		MatchesAt(func(index int, value testLimiter) bool {
+			return value.name == "api" && value.tokens == 5
+		}),
+	).Returns().Once()
+
+	// SUT + act
+	foo(now, testLimiter{name: "api", tokens: 5, mutex: &sync.Mutex{}})
+}
+
+func TestMocker_ShouldMockFunctionWithImplementsMatcher(t *testing.T) {
+	// arrange
+	var foo = func(any) {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(Implements[io.Reader]()).Returns().Once()
+
+	// SUT + act
+	foo(&bytes.Buffer{})
+}
+
+func TestMocker_ShouldReportTestFailureWhenImplementsMatcherFails(t *testing.T) {
+	// arrange
+	var foo = func(any) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect value implementing %v, actual %v of type %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 7, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "io.Reader", fmt.Sprint(args[3]), "tester.Errorf called with different argument 4")
+		switch errorfCalled {
+		case 1:
+			assertEquals(t, 1, args[4], "tester.Errorf called with different argument 5")
+			assertEquals(t, "int", fmt.Sprint(args[5]), "tester.Errorf called with different argument 6")
+		case 2:
+			assertEquals(t, nil, args[4], "tester.Errorf called with different argument 5")
+			assertEquals(t, "<nil>", fmt.Sprint(args[5]), "tester.Errorf called with different argument 6")
+		}
+	}
+	m.Mock(foo).Expects(Implements[io.Reader]()).Returns().Twice()
+
+	// SUT + act
+	foo(1)
+	foo(nil)
+
+	// assert
+	assertEquals(t, 2, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldMockFunctionWithSomeErrorMatcher(t *testing.T) {
+	// arrange
+	var foo = func(error, any) {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(SomeError(), SomeError()).Returns().Once()
+
+	// SUT + act
+	foo(errors.New("some error"), fmt.Errorf("wrapped: %w", context.Canceled))
+}
+
+type testError struct{}
+
+func (e *testError) Error() string {
+	return "test error"
+}
+
+func TestMocker_ShouldReportTestFailureWhenSomeErrorMatcherFails(t *testing.T) {
+	// arrange
+	var foo = func(any) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		switch errorfCalled {
+		case 1, 2:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect a non-nil error, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, 5, len(args), "tester.Errorf called with different number of args")
+		case 3:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect a non-nil error, actual %v of non-error type %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, 6, len(args), "tester.Errorf called with different number of args")
+			assertEquals(t, "oops", args[3], "tester.Errorf called with different argument 4")
+		}
+	}
+	m.Mock(foo).Expects(SomeError()).Returns().Times(3)
+
+	// SUT + act
+	foo(nil)
+	foo((*testError)(nil))
+	foo("oops")
+
+	// assert
+	assertEquals(t, 3, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldMockFunctionWithValidJSONMatcher(t *testing.T) {
+	// arrange
+	var foo = func(string, []byte, any) {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(ValidJSON(), ValidJSON(), ValidJSON()).Returns().Once()
+
+	// SUT + act
+	foo(`{"id": 1, "tags": ["a"]}`, []byte(`[1, 2]`), json.RawMessage(`"text"`))
+}
+
+func TestMocker_ShouldReportTestFailureWhenValidJSONMatcherFails(t *testing.T) {
+	// arrange
+	var foo = func(any) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		switch errorfCalled {
+		case 1, 2:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect valid JSON, actual %q (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, 5, len(args), "tester.Errorf called with different number of args")
+		case 3:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect valid JSON, actual %v of non-string type %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, 6, len(args), "tester.Errorf called with different number of args")
+			assertEquals(t, 42, args[3], "tester.Errorf called with different argument 4")
+		case 4:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect valid JSON, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, 5, len(args), "tester.Errorf called with different number of args")
+		}
+	}
+	m.Mock(foo).Expects(ValidJSON()).Returns().Times(4)
+
+	// SUT + act
+	foo(`{"id": 1,}`)
+	foo([]byte(`{"id"`))
+	foo(42)
+	foo(nil)
+
+	// assert
+	assertEquals(t, 4, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldMockFunctionWithTimeWithinMatcher(t *testing.T) {
+	// arrange
+	var foo = func(time.Time, *time.Time) {}
+	var expected = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var actual = expected.Add(5 * time.Millisecond)
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(TimeWithin(expected, 10*time.Millisecond), TimeWithin(expected, 10*time.Millisecond)).Returns().Once()
+
+	// SUT + act
+	foo(actual, &actual)
+}
+
+func TestMocker_ShouldReportTestFailureWhenTimeWithinMatcherFails(t *testing.T) {
+	// arrange
+	var foo = func(any) {}
+	var expected = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var actual = expected.Add(-5 * time.Millisecond)
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, 8, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, time.Millisecond, args[3], "tester.Errorf called with different argument 4")
+		assertEquals(t, expected, args[4], "tester.Errorf called with different argument 5")
+		switch errorfCalled {
+		case 1:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect time within %v of %v, actual %v off by %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, actual, args[5], "tester.Errorf called with different argument 6")
+			assertEquals(t, 5*time.Millisecond, args[6], "tester.Errorf called with different argument 7")
+		case 2:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect time within %v of %v, actual %v of type %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, "now", args[5], "tester.Errorf called with different argument 6")
+			assertEquals(t, "string", fmt.Sprint(args[6]), "tester.Errorf called with different argument 7")
+		}
+	}
+	m.Mock(foo).Expects(TimeWithin(expected, time.Millisecond)).Returns().Twice()
+
+	// SUT + act
+	foo(actual)
+	foo("now")
+
+	// assert
+	assertEquals(t, 2, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldMockFunctionWithFloatNearMatcher(t *testing.T) {
+	// arrange
+	var foo = func(float64, float32, float64) {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(FloatNear(0.3, 1e-9), FloatNear(1.5, 0.01), FloatNearOrNaN(math.NaN(), 0)).Returns().Once()
+
+	// SUT + act
+	foo(0.1+0.2, 1.505, math.NaN())
+}
+
+func TestMocker_ShouldReportTestFailureWhenFloatNearMatcherFails(t *testing.T) {
+	// arrange
+	var foo = func(any) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, 0.5, args[3], "tester.Errorf called with different argument 4")
+		switch errorfCalled {
+		case 1:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect float within %v of %v, actual %v off by %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, 8, len(args), "tester.Errorf called with different number of args")
+			assertEquals(t, 1.0, args[4], "tester.Errorf called with different argument 5")
+			assertEquals(t, 2.0, args[5], "tester.Errorf called with different argument 6")
+			assertEquals(t, 1.0, args[6], "tester.Errorf called with different argument 7")
+		case 2:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect float within %v of %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, 7, len(args), "tester.Errorf called with different number of args")
+			assertEquals(t, true, math.IsNaN(args[5].(float64)), "tester.Errorf called with different argument 6")
+		case 3:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect float within %v of %v, actual %v of type %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, 8, len(args), "tester.Errorf called with different number of args")
+			assertEquals(t, 1, args[5], "tester.Errorf called with different argument 6")
+			assertEquals(t, "int", fmt.Sprint(args[6]), "tester.Errorf called with different argument 7")
+		}
+	}
+	m.Mock(foo).Expects(FloatNear(1, 0.5)).Returns().Times(3)
+
+	// SUT + act
+	foo(2.0)
+	foo(math.NaN())
+	foo(1)
+
+	// assert
+	assertEquals(t, 3, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportTestFailureWhenFloatNearMatcherExpectsNaN(t *testing.T) {
+	// arrange
+	var foo = func(float64) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect float within %v of %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+	}
+	m.Mock(foo).Expects(FloatNear(math.NaN(), 1)).Returns().Once()
+
+	// SUT + act
+	foo(math.NaN())
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+type testOrder struct {
+	ID       int
+	Customer string
+	Total    float64
+	Created  time.Time
+	Notes    []string
+}
+
+func TestMocker_ShouldMockFunctionWithFieldsMatchMatcher(t *testing.T) {
+	// arrange
+	var foo = func(testOrder, *testOrder) {}
+	var expected = testOrder{ID: 1, Customer: "bar", Total: 9.5, Created: time.Now(), Notes: []string{"a"}}
+	var actual = testOrder{ID: 1, Customer: "bar", Total: 10, Notes: []string{"b", "c"}}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(FieldsMatch(expected, "ID", "Customer"), FieldsMatch(&expected, "ID", "Customer")).Returns().Once()
+
+	// SUT + act
+	foo(actual, &actual)
+}
+
+func TestMocker_ShouldReportTestFailureWhenFieldsMatchMatcherFails(t *testing.T) {
+	// arrange
+	var foo = func(any) {}
+	var expected = testOrder{ID: 1, Customer: "bar"}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		switch errorfCalled {
+		case 1:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect field %v to be %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, 7, len(args), "tester.Errorf called with different number of args")
+			assertEquals(t, "Customer", args[3], "tester.Errorf called with different argument 4")
+			assertEquals(t, "bar", args[4], "tester.Errorf called with different argument 5")
+			assertEquals(t, "baz", args[5], "tester.Errorf called with different argument 6")
+		case 2:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect struct of type %v, actual %v of type %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, 7, len(args), "tester.Errorf called with different number of args")
+			assertEquals(t, "bar", args[4], "tester.Errorf called with different argument 5")
+		case 3:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect exported field %v in struct of type %v, actual none (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, 6, len(args), "tester.Errorf called with different number of args")
+			assertEquals(t, "Missing", args[3], "tester.Errorf called with different argument 4")
+		}
+	}
+	m.Mock(foo).Expects(FieldsMatch(expected, "ID", "Customer")).Returns().Twice()
+	m.Mock(foo).Expects(FieldsMatch(expected, "Missing")).Returns().Once()
+
+	// SUT + act
+	foo(testOrder{ID: 1, Customer: "baz"})
+	foo("bar")
+	foo(expected)
+
+	// assert
+	assertEquals(t, 3, errorfCalled, "tester.Errorf called with different times")
+}
+
+type testUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type testRequest struct {
+	User  testUser `json:"user"`
+	Roles []string `json:"roles"`
+}
+
+func TestMocker_ShouldMockFunctionWithJSONPathMatcher(t *testing.T) {
+	// arrange
+	var foo = func(testRequest) {}
+	var request = testRequest{
+		User:  testUser{ID: 42, Name: "bar"},
+		Roles: []string{"admin", "owner"},
+	}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(JSONPath("user.id", FloatNear(42, 0))).Returns().Once()
+	m.Mock(foo).Expects(JSONPath("roles.1", Matches(func(value any) bool {
+		return value == "owner"
+	}))).Returns().Once()
+
+	// SUT + act
+	foo(request)
+	foo(request)
+}
+
+func TestMocker_ShouldReportTestFailureWhenJSONPathMatcherFails(t *testing.T) {
+	// arrange
+	var foo = func(any) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "user.id", args[3], "tester.Errorf called with different argument 4")
+		switch errorfCalled {
+		case 1:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: at JSON path %v: expect float within %v of %v, actual %v off by %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, 9, len(args), "tester.Errorf called with different number of args")
+			assertEquals(t, 7.0, args[6], "tester.Errorf called with different argument 7")
+		case 2:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect JSON path %v, actual %s missing segment %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, 7, len(args), "tester.Errorf called with different number of args")
+			assertEquals(t, "id", args[5], "tester.Errorf called with different argument 6")
+		case 3:
+			assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect JSON path %v, actual %v not marshalable to JSON: %v (declared at %v)", format, "tester.Errorf called with different message")
+			assertEquals(t, 7, len(args), "tester.Errorf called with different number of args")
+		}
+	}
+	m.Mock(foo).Expects(JSONPath("user.id", FloatNear(42, 0))).Returns().Times(3)
+
+	// SUT + act
+	foo(testRequest{User: testUser{ID: 7}})
+	foo(map[string]any{"user": "bar"})
+	foo(func() {})
+
+	// assert
+	assertEquals(t, 3, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldAggregateVariadicParameterMismatchesOfOneCall(t *testing.T) {
+	// arrange
+	var foo = func(string, ...int) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Parameter mismatch at call #%v: %v of %v parameters matched\n\tparameter #%v: expect %v, actual %v\n\tparameter #%v: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 11, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, 1, args[2], "tester.Errorf called with different argument 3")
+		assertEquals(t, 3, args[3], "tester.Errorf called with different argument 4")
+		assertEquals(t, "[2 1 2 2 2 1]", fmt.Sprint(args[4:10]), "tester.Errorf called with different arguments 5-10")
+	}
+	m.Mock(foo).Expects("a", 1, 2).Returns().Once()
+
+	// SUT + act
+	foo("a", 2, 1)
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldAbortOnFirstParameterMismatchWithStrictParams(t *testing.T) {
+	// arrange
+	var foo = func(int) int { return 0 }
+	var tester = &tester{t: t}
+	var fatalfCalled = 0
+	var results = []int{}
+	var done = make(chan struct{})
+
+	// mock
+	var m = NewMocker(tester, WithStrictParams())
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		fatalfCalled++
+		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect %v, actual %v (declared at %v)\nnote: arguments match the expectation declared at %v (call #%v)", format, "tester.Fatalf called with different message")
+		assertEquals(t, 8, len(args), "tester.Fatalf called with different number of args")
+		assertEquals(t, 2, args[1], "tester.Fatalf called with different argument 2")
+		assertEquals(t, 2, args[3], "tester.Fatalf called with different argument 4")
+		assertEquals(t, 3, args[4], "tester.Fatalf called with different argument 5")
+		assertEquals(t, 3, args[7], "tester.Fatalf called with different argument 8")
+		runtime.Goexit()
+	}
+	tester.errorf = func(format string, args ...interface{}) {
+		t.Errorf("tester.Errorf called unexpectedly: "+format, args...)
+	}
+	m.Mock(foo).Expects(1).Returns(10).Once()
+	m.Mock(foo).Expects(2).Returns(20).Once()
+	m.Mock(foo).Expects(3).Returns(30).Once()
+
+	// SUT + act
+	go func() {
+		defer close(done)
+		for _, value := range []int{1, 3, 3} {
+			results = append(results, foo(value))
+		}
+	}()
+	<-done
+
+	// assert
+	assertEquals(t, 1, fatalfCalled, "tester.Fatalf called with different times")
+	assertEquals(t, "[10]", fmt.Sprint(results), "results different")
+	tester.errorf = func(format string, args ...interface{}) {}
+}
+
+func TestMocker_ShouldReportDynamicTypesWhenParameterTypesDiffer(t *testing.T) {
+	// arrange
+	var foo = func(any) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect %v (%v), actual %v (%v) (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 8, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "expect 5 (int), actual 5 (int64)", fmt.Sprintf("expect %v (%v), actual %v (%v)", args[3:7]...), "tester.Errorf called with different arguments")
+	}
+	m.Mock(foo).Expects(5).Returns().Once()
+
+	// SUT + act
+	foo(int64(5))
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportStructFieldNamesInParameterMismatch(t *testing.T) {
+	// arrange
+	type point struct {
+		X int
+		Y int
+	}
+	var foo = func(point) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 6, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "gomocker.point{X:1, Y:2}", args[3], "tester.Errorf called with different argument 4")
+		assertEquals(t, "gomocker.point{X:2, Y:1}", args[4], "tester.Errorf called with different argument 5")
+	}
+	m.Mock(foo).Expects(point{X: 1, Y: 2}).Returns().Once()
+
+	// SUT + act
+	foo(point{X: 2, Y: 1})
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldHintClosestExpectationOnParameterMismatch(t *testing.T) {
+	// arrange
+	var foo = func(string) {}
+	var tester = &tester{t: t}
+	var messages = []string{}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		messages = append(messages, fmt.Sprintf(format, args...))
+	}
+	m.Mock(foo).Named("foo").Expects("a").Returns().Once()
+	m.Mock(foo).Expects("b").Returns().Once()
+	m.Mock(foo).Expects("c").Returns().Once()
+	var _, _, line, _ = runtime.Caller(0)
+
+	// SUT + act
+	foo("a")
+	foo("c")
+	foo("d")
+
+	// assert
+	assertEquals(t, 2, len(messages), "tester.Errorf called with different times")
+	assertEquals(t, fmt.Sprintf("note: arguments match the expectation declared at gomocker_test.go:%v (call #3)", line-1), messages[0][strings.LastIndex(messages[0], "\n")+1:], "first message different")
+	assertEquals(t, false, strings.Contains(messages[1], "note:"), "second message different")
+}
+
+func TestMocker_ShouldMockFunctionWithMapContainsMatcher(t *testing.T) {
+	// arrange
+	var foo = func(map[string]int) {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(MapContains(map[string]int{"a": 1, "b": 2})).Returns().Once()
+
+	// SUT + act
+	foo(map[string]int{"a": 1, "b": 2, "c": 3})
+}
+
+func TestMocker_ShouldReportTestFailureWhenMapContainsMatcherFails(t *testing.T) {
+	// arrange
+	var foo = func(any) {}
+	var tester = &tester{t: t}
+	var messages = []string{}
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		messages = append(messages, strings.Split(fmt.Sprintf(format, args...), " (declared at ")[0])
+	}
+	m.Mock(foo).Named("foo").Expects(MapContains(map[string]int{"a": 1, "b": 2})).Returns().Times(3)
+
+	// SUT + act
+	foo(map[string]int{"a": 1, "b": 3, "c": 3})
+	foo(map[string]int{"b": 2})
+	foo(map[string]string{"a": "1"})
+
+	// assert
+	assertEquals(t, 3, len(messages), "tester.Errorf called with different times")
+	assertEquals(t, "[foo] Parameter mismatch at call #1 parameter #1: expect 2 for key b, actual 3", messages[0], "first message different")
+	assertEquals(t, "[foo] Parameter mismatch at call #2 parameter #1: expect map containing key a, actual map[b:2]", messages[1], "second message different")
+	assertEquals(t, "[foo] Parameter mismatch at call #3 parameter #1: expect map containing map[a:1 b:2], actual map[a:1] of type map[string]string", messages[2], "third message different")
+}
+
+func TestMocker_ShouldBoundHugeValuesInParameterMismatch(t *testing.T) {
+	// arrange
+	var foo = func([]byte, string, []int) {}
+	var payload = bytes.Repeat([]byte{0xab}, 4096)
+	var text = strings.Repeat("a", 1000)
+	var numbers = make([]int, 1000)
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, 14, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "[]byte(len=4096) "+strings.Repeat("ab", 32)+"...", args[6], "tester.Errorf called with different argument 7")
+		assertEquals(t, strings.Repeat("a", 256)+"...(len=1000)", args[9], "tester.Errorf called with different argument 10")
+		assertEquals(t, "["+strings.Repeat("0 ", 127)+"0...", args[12], "tester.Errorf called with different argument 13")
+	}
+	m.Mock(foo).Expects(nil, "b", []int{}).Returns().Once()
+
+	// SUT + act
+	foo(payload, text, numbers)
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldBoundHugeValuesOnRuneBoundaryInParameterMismatch(t *testing.T) {
+	// arrange
+	var foo = func(string, []string) {}
+	var text = "a" + strings.Repeat("é", 500)
+	var texts = []string{strings.Repeat("é", 500)}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "a"+strings.Repeat("é", 127)+"...(len=1001)", args[6], "tester.Errorf called with different argument 7")
+		assertEquals(t, "["+strings.Repeat("é", 127)+"...", args[9], "tester.Errorf called with different argument 10")
+	}
+	m.Mock(foo).Expects("b", []string{}).Returns().Once()
+
+	// SUT + act
+	foo(text, texts)
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldRenderHugeValuesInFullWithVerboseValues(t *testing.T) {
+	// arrange
+	var foo = func(string) {}
+	var text = strings.Repeat("a", 1000)
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester, WithVerboseValues())
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, text, args[4], "tester.Errorf called with different argument 5")
+	}
+	m.Mock(foo).Expects("b").Returns().Once()
+
+	// SUT + act
+	foo(text)
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldMatchNilAndEmptyWithNilAndEmptyEqual(t *testing.T) {
+	// arrange
+	var foo = func([]int, map[string]int, any, []int) {}
+
+	// mock
+	var m = NewMocker(t, WithNilAndEmptyEqual())
+
+	// expect
+	m.Mock(foo).Expects([]int(nil), map[string]int{}, nil, []int{}).Returns().Once()
+
+	// SUT + act
+	foo([]int{}, nil, []string{}, nil)
+}
+
+func TestMocker_ShouldReportTestFailureForNilAndEmptyWithoutNilAndEmptyEqual(t *testing.T) {
+	// arrange
+	var foo = func([]int, []int) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Parameter mismatch at call #%v: %v of %v parameters matched\n\tparameter #%v: expect %v, actual %v\n\tparameter #%v: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+	}
+	m.Mock(foo).Expects([]int(nil), nil).Returns().Once()
+
+	// SUT + act
+	foo([]int{}, []int{})
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldCallPostSideEffectWithReturns(t *testing.T) {
+	// arrange
+	var foo = func(int) (int, error) { return 0, nil }
+	var dummyError = errors.New("some error")
+	var observed = []string{}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(1).ReturnsSequence([]any{10, nil}, []any{0, dummyError}).PostSideEffect(func(index int, returns ...interface{}) {
+		observed = append(observed, fmt.Sprint(index, returns))
+	}).Twice()
+
+	// SUT + act
+	var result1, err1 = foo(1)
+	var result2, err2 = foo(1)
+
+	// assert
+	assertEquals(t, 10, result1, "result1 different")
+	assertEquals(t, nil, err1, "err1 different")
+	assertEquals(t, 0, result2, "result2 different")
+	assertEquals(t, dummyError, err2, "err2 different")
+	assertEquals(t, "[1 [10 <nil>] 2 [0 some error]]", fmt.Sprint(observed), "observed different")
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingPostSideEffect(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to PostSideEffect without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.PostSideEffect(nil)
+}
+
+func TestMocker_ShouldOverrideReturnsWithConditionalReturn(t *testing.T) {
+	// arrange
+	var foo = func(string) (int, error) { return 0, nil }
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(foo).Returns(1, nil).ConditionalReturn(func(args []any) ([]any, bool) {
+		if args[0] == "bad" {
+			return []any{0, dummyError}, true
+		}
+		return nil, false
+	}).AnyTimes()
+
+	// SUT + act
+	var result1, err1 = foo("good")
+	var result2, err2 = foo("bad")
+
+	// assert
+	assertEquals(t, 1, result1, "result1 different")
+	assertEquals(t, nil, err1, "err1 different")
+	assertEquals(t, 0, result2, "result2 different")
+	assertEquals(t, dummyError, err2, "err2 different")
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingConditionalReturn(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to ConditionalReturn without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.ConditionalReturn(nil)
+}
+
+func TestMocker_ShouldNotifyEachExecution(t *testing.T) {
+	// arrange
+	var flush = func() error { return nil }
+	var notified = make(chan struct{})
+	var finished = make(chan struct{})
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(flush).Expects().Returns(nil).Notify(notified).Times(3)
+
+	// SUT
+	go func() {
+		defer close(finished)
+		for i := 0; i < 3; i++ {
+			flush()
+		}
+	}()
+
+	// act
+	var count = 0
+	for i := 0; i < 3; i++ {
+		<-notified
+		count++
+	}
+	<-finished
+
+	// assert
+	assertEquals(t, 3, count, "notifications different")
+	assertEquals(t, 3, m.CallCount(flush), "call count different")
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingNotify(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to Notify without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.Notify(nil)
+}
+
+func TestMocker_ShouldTrackReturnedCleanupFunctions(t *testing.T) {
+	// arrange
+	var open = func(string) (int, func()) { return 0, nil }
+	var closed = 0
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(open).Returns(1, func() { closed++ }).TrackCleanup().AnyTimes()
+
+	// SUT
+	var _, cleanup1 = open("foo")
+	var _, cleanup2 = open("bar")
+
+	// act
+	var before = m.CleanupCalled(open)
+	cleanup1()
+	var partial = m.CleanupCalled(open)
+	cleanup2()
+	var after = m.CleanupCalled(open)
+
+	// assert
+	assertEquals(t, false, before, "cleanup called different before cleanup")
+	assertEquals(t, false, partial, "cleanup called different after partial cleanup")
+	assertEquals(t, true, after, "cleanup called different after cleanup")
+	assertEquals(t, 2, closed, "closed different")
+}
+
+func TestMocker_ShouldNotReportCleanupCalledIfNothingTracked(t *testing.T) {
+	// arrange
+	var open = func() func() { return nil }
+	var close = func() {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(open).Expects().Returns(nil).TrackCleanup().Once()
+
+	// SUT + act
+	var cleanup = open()
+
+	// assert
+	assertEquals(t, true, cleanup == nil, "cleanup different")
+	assertEquals(t, false, m.CleanupCalled(open), "cleanup called different")
+	assertEquals(t, false, m.CleanupCalled(close), "cleanup called different for never setup")
+}
+
+func TestMocker_ShouldReportErrorIfNoFormerSetupWhenCallingTrackCleanup(t *testing.T) {
+	// arrange
+	var tester = &tester{t: t}
+
+	// expect
+	tester.fatalf = func(format string, args ...interface{}) {
+		assertEquals(t, "Unexpected call to TrackCleanup without setting up an anticipated function or method", format, "tester.Fatalf called with different message")
+		assertEquals(t, 0, len(args), "tester.Fatalf called with different number of args")
+	}
+
+	// SUT
+	var m = &mocker{
+		tester: tester,
+	}
+
+	// act
+	m.TrackCleanup()
+}
+
+func TestMocker_ShouldReportUnmetExpectationsInSetupOrder(t *testing.T) {
+	// arrange
+	var funcs = []func(){
+		func() { _ = 0 },
+		func() { _ = 1 },
+		func() { _ = 2 },
+		func() { _ = 3 },
+		func() { _ = 4 },
+		func() { _ = 5 },
+	}
+	var tester = &tester{t: t}
+	var reported = []interface{}{}
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		reported = append(reported, args[0])
+	}
+	var expected = []interface{}{}
+	for i := len(funcs) - 1; i >= 0; i-- {
+		var name = fmt.Sprint("func", i)
+		m.Mock(funcs[i]).Named(name).Expects().Returns().Once()
+		expected = append(expected, name)
+	}
+
+	// act
+	m.verifyAll()
+
+	// assert
+	assertEquals(t, fmt.Sprint(expected), fmt.Sprint(reported), "reported order different")
+}
+
+func TestMocker_ShouldMockFunctionWithDirectionalChannels(t *testing.T) {
+	// arrange
+	var foo = func(<-chan int) chan<- int { return nil }
+	var input = make(chan int)
+	var output = make(chan int, 1)
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock(foo).Expects(input).Returns(output).Once()
+
+	// SUT + act
+	var result = foo(input)
+	result <- 1
+
+	// assert
+	assertEquals(t, 1, <-output, "result channel different")
+}
+
+func TestMocker_ShouldReportTestFailureWhenDirectionalChannelMismatch(t *testing.T) {
+	// arrange
+	var foo = func(<-chan int) {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Parameter mismatch at call #%v parameter #%v: expect %v, actual %v (declared at %v)", format, "tester.Errorf called with different message")
+	}
+	m.Mock(foo).Expects(make(chan int)).Returns().Once()
+
+	// SUT + act
+	foo(make(chan int))
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportTestFailureWhenSetupIsIncompleteAtCleanup(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester).(*mocker)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "A former setup for function or method [%v] was incomplete."+
+			" Did you miss calling the Once/Twice/Times method in the end?", format, "tester.Errorf called with different message")
+		assertEquals(t, 1, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, "foo", args[0], "tester.Errorf called with different argument 1")
+	}
+	m.Mock(foo).Named("foo").Expects().Returns()
+
+	// act
+	m.verifyAll()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldReportUnusedStubsWithOption(t *testing.T) {
+	// arrange
+	var foo = func() {}
+	var bar = func() {}
+	var tester = &tester{t: t}
+	var errorfCalled = 0
+
+	// mock
+	var m = NewMocker(tester, WithReportUnusedStubs()).(*mocker)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {
+		errorfCalled++
+		assertEquals(t, "[%v] Stub was never called (declared at %v)", format, "tester.Errorf called with different message")
+		assertEquals(t, 2, len(args), "tester.Errorf called with different number of args")
+		assertEquals(t, true, strings.HasSuffix(fmt.Sprint(args[0]), ".func2"), "tester.Errorf called with different argument 1")
+	}
+	m.Stub(foo).Returns().AnyTimes()
+	m.Stub(bar).Returns().AnyTimes()
+
+	// SUT
+	foo()
+
+	// act
+	m.verifyAll()
+
+	// assert
+	assertEquals(t, 1, errorfCalled, "tester.Errorf called with different times")
+}
+
+func TestMocker_ShouldLogEveryCallWithDebugLog(t *testing.T) {
+	// arrange
+	var foo = func(int, string) (string, error) { return "", nil }
+	var tester = &tester{t: t}
+	var logged = []string{}
+
+	// mock
+	var m = NewMocker(tester, WithDebugLog()).(*mocker)
+
+	// expect
+	tester.errorf = func(format string, args ...interface{}) {}
+	tester.logf = func(format string, args ...interface{}) {
+		assertEquals(t, "[%v] call #%v (%v) handled by %v, returning (%v)", format, "tester.Logf called with different message")
+		assertEquals(t, 5, len(args), "tester.Logf called with different number of args")
+		var handler = fmt.Sprint(args[3])
+		if strings.HasPrefix(handler, "setup declared at gomocker_test.go:") {
+			handler = "setup declared"
+		}
+		logged = append(logged, fmt.Sprintf("[%v] call #%v (%v) %v (%v)", args[0], args[1], args[2], handler, args[4]))
+	}
+	m.Mock(foo).Named("foo").Expects(1, "a").Returns("b", nil).Once()
+
+	// SUT + act
+	foo(1, "a")
+	foo(2, "c")
+
+	// assert
+	assertEquals(t, "[foo] call #1 (1, a) setup declared (b, <nil>)", logged[0], "first log different")
+	assertEquals(t, "[foo] call #2 (2, c) no setup (, <nil>)", logged[1], "second log different")
+	assertEquals(t, 2, len(logged), "tester.Logf called with different times")
+	tester.logf = nil
+	m.verifyAll()
+}
+
+func TestMocker_ShouldNotReportUnusedStubsByDefault(t *testing.T) {
+	// arrange
+	var foo = func() {}
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Stub(foo).Returns().AnyTimes()
+}
+
+type testStorage interface {
+	Load(key string) ([]byte, error)
+	Save(ctx context.Context, key string, values ...[]byte) error
+}
+
+// testMockStorage is the source code generated by mockgen.GenerateInterfaceMock[testStorage](writer, "gomocker", "testMockStorage")
+type testMockStorage struct{}
+
+// Load is to be setup by Mock or Stub methods of a mocker
+func (*testMockStorage) Load(p0 string) ([]uint8, error) {
+	panic("testMockStorage.Load is not mocked")
+}
+
+// Save is to be setup by Mock or Stub methods of a mocker
+func (*testMockStorage) Save(p0 context.Context, p1 string, p2 ...[]uint8) error {
+	panic("testMockStorage.Save is not mocked")
+}
+
+func TestMocker_ShouldMockGeneratedInterfaceImplementation(t *testing.T) {
+	// arrange
+	var storage testStorage = &testMockStorage{}
+	var dummyError = errors.New("some error")
+
+	// mock
+	var m = NewMocker(t)
+
+	// expect
+	m.Mock((*testMockStorage).Load).Expects(storage, "a").Returns([]byte("x"), nil).Once()
+	m.Mock((*testMockStorage).Save).Expects(storage, Anything(), "a", []byte("y")).Returns(dummyError).Once()
+
+	// SUT + act
+	var value, err1 = storage.Load("a")
+	var err2 = storage.Save(context.Background(), "a", []byte("y"))
+
+	// assert
+	assertEquals(t, "x", string(value), "value different")
+	assertEquals(t, nil, err1, "err1 different")
+	assertEquals(t, dummyError, err2, "err2 different")
+}
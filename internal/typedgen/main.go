@@ -0,0 +1,269 @@
+// Command typedgen writes typed_gen.go, the compile-time safe wrappers of Expecter and Returner for each supported arity
+//
+//	run it through go generate from the root of the module, i.e. `go generate ./...`
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+)
+
+const (
+	maxParams  = 4
+	maxReturns = 3
+	outputFile = "typed_gen.go"
+)
+
+var counts = []string{"no", "one", "two", "three", "four"}
+
+// arity describes a combination of parameter and return counts to generate the wrappers for
+type arity struct {
+	Params  int
+	Returns int
+}
+
+// Name returns the suffix of the generated names, e.g. 2x1 for two parameters and one return
+func (a arity) Name() string {
+	return fmt.Sprint(a.Params, "x", a.Returns)
+}
+
+// Describe returns the wording of the counts in doc comments, e.g. "two parameters and one return"
+func (a arity) Describe() string {
+	return fmt.Sprint(plural(a.Params, "parameter", "parameters"), " and ", plural(a.Returns, "return", "returns"))
+}
+
+// TypeParams returns the type parameter list of the generated types, e.g. [P1, P2, R1 any], or empty for none
+func (a arity) TypeParams() string {
+	var names = append(names("P", a.Params), names("R", a.Returns)...)
+	if len(names) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(names, ", ") + " any]"
+}
+
+// TypeArgs returns the type argument list of the generated types, e.g. [P1, P2, R1], or empty for none
+func (a arity) TypeArgs() string {
+	var names = append(names("P", a.Params), names("R", a.Returns)...)
+	if len(names) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+// Func returns the function type accepted by the generated constructor, e.g. func(P1, P2) (R1, R2)
+func (a arity) Func() string {
+	var signature = "func(" + strings.Join(names("P", a.Params), ", ") + ")"
+	switch a.Returns {
+	case 0:
+		return signature
+	case 1:
+		return signature + " R1"
+	}
+	return signature + " (" + strings.Join(names("R", a.Returns), ", ") + ")"
+}
+
+// Returner returns the typed Returner for the returns, e.g. TypedReturner2[R1, R2]
+func (a arity) Returner() string {
+	return returner{a.Returns}.Type()
+}
+
+// Typed returns the typed parameters of Expects, e.g. p1 P1, p2 P2
+func (a arity) Typed() string {
+	return parameters("p", "P", a.Params)
+}
+
+// Untyped returns the parameters of ExpectsMatch, e.g. p1 any, p2 any
+func (a arity) Untyped() string {
+	return parameters("p", "any", a.Params)
+}
+
+// Args returns the arguments passed on to Expects, e.g. p1, p2
+func (a arity) Args() string {
+	return strings.Join(names("p", a.Params), ", ")
+}
+
+// returner describes a return count to generate the typed Returner for
+type returner struct {
+	Returns int
+}
+
+// Type returns the name of the typed Returner including its type arguments, e.g. TypedReturner2[R1, R2]
+func (r returner) Type() string {
+	if r.Returns == 0 {
+		return "TypedReturner0"
+	}
+	return fmt.Sprint("TypedReturner", r.Returns, "[", strings.Join(names("R", r.Returns), ", "), "]")
+}
+
+// TypeParams returns the type parameter list of the typed Returner, e.g. [R1, R2 any], or empty for none
+func (r returner) TypeParams() string {
+	if r.Returns == 0 {
+		return ""
+	}
+	return "[" + strings.Join(names("R", r.Returns), ", ") + " any]"
+}
+
+// Describe returns the wording of the count in doc comments, e.g. "two returns" or "no return"
+func (r returner) Describe() string {
+	if r.Returns == 0 {
+		return "no return"
+	}
+	return plural(r.Returns, "return", "returns")
+}
+
+// Typed returns the typed parameters of Returns, e.g. r1 R1, r2 R2
+func (r returner) Typed() string {
+	return parameters("r", "R", r.Returns)
+}
+
+// Args returns the arguments passed on to Returns, e.g. r1, r2
+func (r returner) Args() string {
+	return strings.Join(names("r", r.Returns), ", ")
+}
+
+func plural(count int, singular string, plural string) string {
+	if count == 1 {
+		return counts[count] + " " + singular
+	}
+	return counts[count] + " " + plural
+}
+
+func names(prefix string, count int) []string {
+	var names = make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		names = append(names, fmt.Sprint(prefix, i))
+	}
+	return names
+}
+
+func parameters(prefix string, typePrefix string, count int) string {
+	var parameters = make([]string, 0, count)
+	for i := 1; i <= count; i++ {
+		var typeName = typePrefix
+		if typePrefix != "any" {
+			typeName = fmt.Sprint(typePrefix, i)
+		}
+		parameters = append(parameters, fmt.Sprint(prefix, i, " ", typeName))
+	}
+	return strings.Join(parameters, ", ")
+}
+
+func main() {
+	var returners = make([]returner, 0, maxReturns+1)
+	for returns := 0; returns <= maxReturns; returns++ {
+		returners = append(returners, returner{returns})
+	}
+	var arities = make([]arity, 0, (maxParams+1)*(maxReturns+1))
+	for params := 0; params <= maxParams; params++ {
+		for returns := 0; returns <= maxReturns; returns++ {
+			arities = append(arities, arity{params, returns})
+		}
+	}
+	var source = &bytes.Buffer{}
+	var err = typedTemplate.Execute(source, struct {
+		Returners []returner
+		Arities   []arity
+	}{returners, arities})
+	if err != nil {
+		log.Fatal(err)
+	}
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err = os.WriteFile(outputFile, formatted, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+var typedTemplate = template.Must(template.New(outputFile).Parse(`// Code generated by go run ./internal/typedgen. DO NOT EDIT.
+
+package gomocker
+{{range .Returners}}
+// TypedReturner{{.Returns}} is the compile-time safe Returner for a function or struct method with {{.Describe}}
+//
+//	Labeled is overridden to keep the chain typed, while the methods of Returner other than Returns are promoted as is, e.g. ReturnsZero
+type TypedReturner{{.Returns}}{{.TypeParams}} struct {
+	Returner
+}
+
+// Labeled allows one to attach a label to the current setup shown in its failure messages, just like Labeled of Returner
+func (r *{{.Type}}) Labeled(label string) *{{.Type}} {
+	r.Returner = r.Returner.Labeled(label)
+	return r
+}
+{{if .Returns}}
+// Returns allows one to setup the values to be returned after a function or a struct method call, just like Returns of Returner
+{{- else}}
+// Returns allows one to setup no return after a function or a struct method call, just like Returns of Returner
+{{- end}}
+func (r *{{.Type}}) Returns({{.Typed}}) Counter {
+	return r.Returner.Returns({{.Args}})
+}
+{{end}}
+{{- range .Arities}}
+// TypedMock{{.Name}} is the compile-time safe Expecter for a function or struct method with {{.Describe}}
+//
+//	Named, Labeled, Unordered and Formatted are overridden to keep the chain typed, while other methods of Expecter are promoted as is
+type TypedMock{{.Name}}{{.TypeParams}} struct {
+	Expecter
+}
+
+// Mock{{.Name}} allows one to mock a function or struct method with {{.Describe}} in a compile-time safe way
+//
+//	m pass in the mocker to setup the mock with
+//	fn pass in the function or struct method to be mocked, e.g. (*foo).bar taking the receiver as the first parameter
+//	returns a TypedMock{{.Name}} instance to allow setting up typed parameter expectations
+func Mock{{.Name}}{{.TypeParams}}(m Mocker, fn {{.Func}}) *TypedMock{{.Name}}{{.TypeArgs}} {
+	return &TypedMock{{.Name}}{{.TypeArgs}}{
+		Expecter: m.Mock(fn),
+	}
+}
+
+// Named allows one to override the name shown in failure messages, just like Named of Expecter
+func (e *TypedMock{{.Name}}{{.TypeArgs}}) Named(name string) *TypedMock{{.Name}}{{.TypeArgs}} {
+	e.Expecter = e.Expecter.Named(name)
+	return e
+}
+
+// Labeled allows one to attach a label to the current setup shown in its failure messages, just like Labeled of Expecter
+func (e *TypedMock{{.Name}}{{.TypeArgs}}) Labeled(label string) *TypedMock{{.Name}}{{.TypeArgs}} {
+	e.Expecter = e.Expecter.Labeled(label)
+	return e
+}
+
+// Unordered allows one to dispatch each call to whichever remaining setup matching it, just like Unordered of Expecter
+func (e *TypedMock{{.Name}}{{.TypeArgs}}) Unordered() *TypedMock{{.Name}}{{.TypeArgs}} {
+	e.Expecter = e.Expecter.Unordered()
+	return e
+}
+
+// Formatted allows one to customize how the values are rendered in failure messages, just like Formatted of Expecter
+func (e *TypedMock{{.Name}}{{.TypeArgs}}) Formatted(formatter func(value any) string) *TypedMock{{.Name}}{{.TypeArgs}} {
+	e.Expecter = e.Expecter.Formatted(formatter)
+	return e
+}
+
+// Expects allows one to setup the parameters to be verified during a function or a struct method call, just like Expects of Expecter
+func (e *TypedMock{{.Name}}{{.TypeArgs}}) Expects({{.Typed}}) *{{.Returner}} {
+	return &{{.Returner}}{
+		Returner: e.Expecter.Expects({{.Args}}),
+	}
+}
+{{- if .Params}}
+
+// ExpectsMatch allows one to setup the parameters to be verified using parameter matchers, e.g. Anything()
+//
+//	the number of parameters is still checked at compile time, while each parameter can be either a value or a matcher
+func (e *TypedMock{{.Name}}{{.TypeArgs}}) ExpectsMatch({{.Untyped}}) *{{.Returner}} {
+	return &{{.Returner}}{
+		Returner: e.Expecter.Expects({{.Args}}),
+	}
+}
+{{- end}}
+{{end}}`))
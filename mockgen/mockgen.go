@@ -0,0 +1,273 @@
+// Package mockgen generates the source code of mockable implementations of interfaces, to be mocked by gomocker
+//
+//	it is kept apart from the gomocker package, so that the tests using gomocker do not depend on go/format
+package mockgen
+
+import (
+	"fmt"
+	"go/format"
+	"io"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// GenerateInterfaceMock writes the source code of a mockable implementation of the interface I, e.g. for go:generate
+//
+//	each method of the generated struct simply panics, and is to be setup by Mock or Stub methods of a mocker
+//	the types from the package named the same as packageName are considered local, thus not imported,
+//	while the packages of the same name from different import paths are imported with distinct aliases
+//
+//	writer pass in the writer to receive the generated source code, e.g. os.Stdout or a file
+//	packageName pass in the name of the package the generated source code belongs to
+//	mockName pass in the name of the generated struct
+//	returns an error if I is not an interface or has unexported methods
+func GenerateInterfaceMock[I any](writer io.Writer, packageName string, mockName string) error {
+	var iface = reflect.TypeFor[I]()
+	if iface.Kind() != reflect.Interface {
+		return fmt.Errorf("cannot generate mock for non-interface type %v", iface)
+	}
+	var generator = &mockGenerator{
+		packageName: packageName,
+		aliases:     map[string]string{},
+		paths:       map[string]string{},
+		names:       map[string]string{},
+	}
+	var methods = &strings.Builder{}
+	for i := 0; i < iface.NumMethod(); i++ {
+		var method = iface.Method(i)
+		if !method.IsExported() {
+			return fmt.Errorf("cannot generate mock for interface %v with unexported method %v", iface, method.Name)
+		}
+		fmt.Fprintf(
+			methods,
+			"\n// %v is to be setup by Mock or Stub methods of a mocker\nfunc (*%v) %v%v {\n\tpanic(%q)\n}\n",
+			method.Name,
+			mockName,
+			method.Name,
+			generator.signature(method.Type, true),
+			fmt.Sprint(mockName, ".", method.Name, " is not mocked"),
+		)
+	}
+	var source = &strings.Builder{}
+	fmt.Fprintf(source, "// Code generated by gomocker. DO NOT EDIT.\n\npackage %v\n", packageName)
+	if len(generator.aliases) > 0 {
+		var paths = make([]string, 0, len(generator.aliases))
+		for path := range generator.aliases {
+			paths = append(paths, path)
+		}
+		slices.Sort(paths)
+		fmt.Fprint(source, "\nimport (\n")
+		for _, path := range paths {
+			if alias := generator.aliases[path]; alias != generator.names[path] {
+				fmt.Fprintf(source, "\t%v %q\n", alias, path)
+			} else {
+				fmt.Fprintf(source, "\t%q\n", path)
+			}
+		}
+		fmt.Fprint(source, ")\n")
+	}
+	fmt.Fprintf(
+		source,
+		"\n// %v is a mockable implementation of %v\ntype %v struct{}\n",
+		mockName,
+		generator.describe(iface),
+		mockName,
+	)
+	source.WriteString(methods.String())
+	var formatted, err = format.Source([]byte(source.String()))
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(formatted)
+	return err
+}
+
+// qualifiedName matches a type name qualified by its import path, as rendered in the type arguments by reflect
+var qualifiedName = regexp.MustCompile(`([\w\-.~/]+)\.(\w+)`)
+
+// majorVersion matches the major version suffix of an import path, e.g. v2 in github.com/foo/bar/v2
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+type mockGenerator struct {
+	packageName string
+	// aliases keeps the name each imported path is referred to by in the generated source code
+	aliases map[string]string
+	// paths keeps the import path each alias is taken by, for telling apart the packages of the same name
+	paths map[string]string
+	// names keeps the package name of each import path as declared by the package itself, if known
+	names map[string]string
+}
+
+// describe returns the name of the interface for the doc comment, without importing its package just for the comment
+func (g *mockGenerator) describe(iface reflect.Type) string {
+	var packageName, _, _ = strings.Cut(iface.String(), ".")
+	if iface.Name() != "" && packageName == g.packageName {
+		return iface.Name()
+	}
+	return iface.String()
+}
+
+func (g *mockGenerator) signature(funcType reflect.Type, named bool) string {
+	var params = make([]string, 0, funcType.NumIn())
+	for i := 0; i < funcType.NumIn(); i++ {
+		var param = funcType.In(i)
+		var prefix = ""
+		if named {
+			prefix = fmt.Sprint("p", i, " ")
+		}
+		if funcType.IsVariadic() && i == funcType.NumIn()-1 {
+			params = append(params, prefix+"..."+g.render(param.Elem()))
+		} else {
+			params = append(params, prefix+g.render(param))
+		}
+	}
+	var results = make([]string, 0, funcType.NumOut())
+	for i := 0; i < funcType.NumOut(); i++ {
+		results = append(results, g.render(funcType.Out(i)))
+	}
+	var signature = "(" + strings.Join(params, ", ") + ")"
+	switch len(results) {
+	case 0:
+		return signature
+	case 1:
+		return signature + " " + results[0]
+	}
+	return signature + " (" + strings.Join(results, ", ") + ")"
+}
+
+func (g *mockGenerator) render(value reflect.Type) string {
+	if value.Name() != "" {
+		if value.PkgPath() == "" {
+			return value.Name()
+		}
+		var name, args, generic = strings.Cut(value.Name(), "[")
+		var packageName, _, _ = strings.Cut(value.String(), ".")
+		if generic {
+			name += "[" + g.qualifyArguments(args)
+		}
+		if packageName == g.packageName {
+			return name
+		}
+		g.names[value.PkgPath()] = packageName
+		return g.qualify(value.PkgPath(), packageName) + "." + name
+	}
+	switch value.Kind() {
+	case reflect.Pointer:
+		return "*" + g.render(value.Elem())
+	case reflect.Slice:
+		return "[]" + g.render(value.Elem())
+	case reflect.Array:
+		return fmt.Sprint("[", value.Len(), "]", g.render(value.Elem()))
+	case reflect.Map:
+		return "map[" + g.render(value.Key()) + "]" + g.render(value.Elem())
+	case reflect.Chan:
+		switch value.ChanDir() {
+		case reflect.RecvDir:
+			return "<-chan " + g.render(value.Elem())
+		case reflect.SendDir:
+			return "chan<- " + g.render(value.Elem())
+		}
+		return "chan " + g.render(value.Elem())
+	case reflect.Func:
+		return "func" + g.signature(value, false)
+	case reflect.Struct:
+		return g.renderStruct(value)
+	case reflect.Interface:
+		return g.renderInterface(value)
+	}
+	return value.String()
+}
+
+func (g *mockGenerator) renderStruct(value reflect.Type) string {
+	if value.NumField() == 0 {
+		return "struct{}"
+	}
+	var fields = make([]string, 0, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		var field = value.Field(i)
+		var text = g.render(field.Type)
+		if !field.Anonymous {
+			text = field.Name + " " + text
+		}
+		if field.Tag != "" {
+			text += " " + strconv.Quote(string(field.Tag))
+		}
+		fields = append(fields, text)
+	}
+	return "struct { " + strings.Join(fields, "; ") + " }"
+}
+
+func (g *mockGenerator) renderInterface(value reflect.Type) string {
+	if value.NumMethod() == 0 {
+		return "interface{}"
+	}
+	var methods = make([]string, 0, value.NumMethod())
+	for i := 0; i < value.NumMethod(); i++ {
+		var method = value.Method(i)
+		methods = append(methods, method.Name+g.signature(method.Type, false))
+	}
+	return "interface { " + strings.Join(methods, "; ") + " }"
+}
+
+// qualifyArguments rewrites the type arguments rendered by reflect, replacing each import path by its alias
+//
+//	reflect renders the type arguments qualified by their full import paths, e.g. Pair[string,github.com/foo/bar.Baz]
+func (g *mockGenerator) qualifyArguments(args string) string {
+	return qualifiedName.ReplaceAllStringFunc(args, func(match string) string {
+		var parts = qualifiedName.FindStringSubmatch(match)
+		var path, name = parts[1], parts[2]
+		var packageName = g.names[path]
+		if packageName == "" {
+			packageName = guessName(path)
+			if packageName == path[strings.LastIndex(path, "/")+1:] {
+				g.names[path] = packageName
+			}
+		}
+		if packageName == g.packageName {
+			return name
+		}
+		return g.qualify(path, packageName) + "." + name
+	})
+}
+
+// qualify returns the alias of the import path, taking the package name unless another path already took it
+func (g *mockGenerator) qualify(path string, packageName string) string {
+	if alias, found := g.aliases[path]; found {
+		return alias
+	}
+	var alias = packageName
+	for index := 2; ; index++ {
+		if _, taken := g.paths[alias]; !taken && alias != g.packageName {
+			break
+		}
+		alias = fmt.Sprint(packageName, index)
+	}
+	g.aliases[path] = alias
+	g.paths[alias] = path
+	return alias
+}
+
+// guessName derives a package name from the import path, for the packages only seen in type arguments
+//
+//	a guessed name other than the last element of the path is imported as an alias, which is valid whatever the declared name is
+func guessName(path string) string {
+	var elements = strings.Split(path, "/")
+	var name = elements[len(elements)-1]
+	if majorVersion.MatchString(name) && len(elements) > 1 {
+		name = elements[len(elements)-2]
+	}
+	name, _, _ = strings.Cut(name, ".")
+	name = strings.Map(func(r rune) rune {
+		if r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	if name == "" || ('0' <= name[0] && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
@@ -0,0 +1,146 @@
+package mockgen
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	"sync/atomic"
+	"testing"
+	texttemplate "text/template"
+)
+
+func assertEquals(t *testing.T, expect interface{}, actual interface{}, message string) {
+	t.Helper()
+	if expect == actual {
+		return
+	}
+	t.Errorf(
+		"%v: expect %v, actual %v",
+		message,
+		expect,
+		actual,
+	)
+}
+
+type testStorage interface {
+	Load(key string) ([]byte, error)
+	Save(ctx context.Context, key string, values ...[]byte) error
+}
+
+type testPair[K any, V any] struct {
+	key   K
+	value V
+}
+
+func TestGenerateInterfaceMock_ShouldGenerateMockableImplementation(t *testing.T) {
+	// arrange
+	var writer = &strings.Builder{}
+
+	// SUT + act
+	var err = GenerateInterfaceMock[testStorage](writer, "mockgen", "testMockStorage")
+
+	// assert
+	assertEquals(t, nil, err, "err different")
+	assertEquals(t, `// Code generated by gomocker. DO NOT EDIT.
+
+package mockgen
+
+import (
+	"context"
+)
+
+// testMockStorage is a mockable implementation of testStorage
+type testMockStorage struct{}
+
+// Load is to be setup by Mock or Stub methods of a mocker
+func (*testMockStorage) Load(p0 string) ([]uint8, error) {
+	panic("testMockStorage.Load is not mocked")
+}
+
+// Save is to be setup by Mock or Stub methods of a mocker
+func (*testMockStorage) Save(p0 context.Context, p1 string, p2 ...[]uint8) error {
+	panic("testMockStorage.Save is not mocked")
+}
+`, writer.String(), "generated source different")
+}
+
+func TestGenerateInterfaceMock_ShouldQualifyTypesFromOtherPackages(t *testing.T) {
+	// arrange
+	var writer = &strings.Builder{}
+
+	// SUT + act
+	var err = GenerateInterfaceMock[interface {
+		Lookup(map[string]*bytes.Buffer, [2]func(chan<- error)) chan int
+	}](writer, "main", "mockLookup")
+
+	// assert
+	assertEquals(t, nil, err, "err different")
+	assertEquals(t, true, strings.Contains(writer.String(), "\t\"bytes\"\n"), "generated imports different")
+	assertEquals(t, true, strings.Contains(writer.String(), "func (*mockLookup) Lookup(p0 map[string]*bytes.Buffer, p1 [2]func(chan<- error)) chan int {"), "generated method different")
+}
+
+func TestGenerateInterfaceMock_ShouldQualifyTypeArgumentsOfGenericTypes(t *testing.T) {
+	// arrange
+	var writer = &strings.Builder{}
+
+	// SUT + act
+	var err = GenerateInterfaceMock[interface {
+		Swap(*atomic.Pointer[bytes.Buffer]) testPair[string, []*bytes.Buffer]
+	}](writer, "mockgen", "mockSwap")
+
+	// assert
+	assertEquals(t, nil, err, "err different")
+	assertEquals(t, true, strings.Contains(writer.String(), "import (\n\t\"bytes\"\n\t\"sync/atomic\"\n)\n"), "generated imports different")
+	assertEquals(t, true, strings.Contains(writer.String(), "func (*mockSwap) Swap(p0 *atomic.Pointer[bytes.Buffer]) testPair[string, []*bytes.Buffer] {"), "generated method different")
+}
+
+func TestGenerateInterfaceMock_ShouldQualifyMembersOfStructsAndInterfaces(t *testing.T) {
+	// arrange
+	var writer = &strings.Builder{}
+
+	// SUT + act
+	var err = GenerateInterfaceMock[interface {
+		Decode(struct {
+			*bytes.Buffer
+			Name string `json:"name"`
+		}) interface {
+			Done(context.Context) error
+		}
+	}](writer, "main", "mockDecoder")
+
+	// assert
+	assertEquals(t, nil, err, "err different")
+	assertEquals(t, true, strings.Contains(writer.String(), "import (\n\t\"bytes\"\n\t\"context\"\n)\n"), "generated imports different")
+	assertEquals(t, true, strings.Contains(writer.String(), "func (*mockDecoder) Decode(p0 struct {\n\t*bytes.Buffer\n\tName string \"json:\\\"name\\\"\"\n}) interface{ Done(context.Context) error } {"), "generated method different")
+}
+
+func TestGenerateInterfaceMock_ShouldAliasConflictingPackageNames(t *testing.T) {
+	// arrange
+	var writer = &strings.Builder{}
+
+	// SUT + act
+	var err = GenerateInterfaceMock[interface {
+		Render(*htmltemplate.Template, *texttemplate.Template) *atomic.Pointer[texttemplate.Template]
+	}](writer, "main", "mockRenderer")
+
+	// assert
+	assertEquals(t, nil, err, "err different")
+	assertEquals(t, true, strings.Contains(writer.String(), "import (\n\t\"html/template\"\n\t\"sync/atomic\"\n\ttemplate2 \"text/template\"\n)\n"), "generated imports different")
+	assertEquals(t, true, strings.Contains(writer.String(), "func (*mockRenderer) Render(p0 *template.Template, p1 *template2.Template) *atomic.Pointer[template2.Template] {"), "generated method different")
+}
+
+func TestGenerateInterfaceMock_ShouldReturnErrorForInvalidInterface(t *testing.T) {
+	// arrange
+	var writer = &strings.Builder{}
+
+	// SUT + act
+	var err1 = GenerateInterfaceMock[int](writer, "main", "mockInt")
+	var err2 = GenerateInterfaceMock[interface{ unexported() }](writer, "main", "mockUnexported")
+
+	// assert
+	assertEquals(t, "cannot generate mock for non-interface type int", fmt.Sprint(err1), "err1 different")
+	assertEquals(t, "cannot generate mock for interface interface { mockgen.unexported() } with unexported method unexported", fmt.Sprint(err2), "err2 different")
+	assertEquals(t, "", writer.String(), "generated source different")
+}